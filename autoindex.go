@@ -0,0 +1,83 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import "strconv"
+
+// SetAutoIndex adds, when rendering, a column before the others numbering
+// the rows from 1, right aligned, under the header set by
+// SetAutoIndexHeader, "#" by default. Spacer rows are not numbered, rows
+// appended without cells are. The other columns keep their index for every
+// setting.
+func (t *Table) SetAutoIndex(index bool) {
+	t.autoIndex = index
+}
+
+// SetAutoIndexHeader sets the header of the column added by SetAutoIndex.
+func (t *Table) SetAutoIndexHeader(header string) {
+	t.indexHeader = header
+}
+
+// withIndex returns a copy of the table to render with the column of row
+// numbers added before the others.
+func (t *Table) withIndex() *Table {
+	n := len(t.cs)
+	index := make(map[int]int, n)
+	for y := 0; y < n; y++ {
+		index[y] = y + 1
+	}
+	c := t.moveColumns(index)
+	c.autoIndex = false
+
+	width := 0
+	number := 0
+	for i := range c.lines {
+		var cell []string
+		if !t.isSpacer(i) {
+			number++
+			cell = []string{strconv.Itoa(number)}
+			width = DisplayWidth(cell[0])
+			// A row without cells is a line tall once numbered
+			if c.rs[i] < 1 {
+				c.rs[i] = 1
+			}
+		}
+		for len(c.lines[i]) == 0 {
+			c.lines[i] = append(c.lines[i], nil)
+		}
+		c.lines[i][0] = cell
+	}
+
+	if len(t.headers) > 0 {
+		header := c.parseDimension(t.indexHeader, 0, headerRowIdx)
+		if len(t.headerSpans) > 0 {
+			c.headers = append([][]string{header}, t.headers...)
+			c.headerSpans = append([]int{1}, t.headerSpans...)
+		} else {
+			c.headers[0] = header
+		}
+	}
+	if len(t.headerGroups) > 0 {
+		c.headerGroups = append([]HeaderCell{{Span: 1}}, t.allHeaderGroups()...)
+	}
+	if width > c.cs[0] {
+		c.cs[0] = width
+	}
+
+	// The other columns keep their alignment only when they all have one,
+	// as fillAlignment would otherwise reset them.
+	c.columnsAlign = make([]int, n+1)
+	for y := 0; y < n; y++ {
+		c.columnsAlign[y+1] = t.align
+		if len(t.columnsAlign) >= n {
+			c.columnsAlign[y+1] = t.columnsAlign[y]
+		}
+	}
+	c.columnsAlign[0] = ALIGN_RIGHT
+	return c
+}
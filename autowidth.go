@@ -0,0 +1,234 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// terminalWidth returns the number of columns of the terminal w writes to,
+// if any.
+var terminalWidth = func(w io.Writer) (int, bool) {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0, false
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 {
+		return 0, false
+	}
+	return width, true
+}
+
+// ShrinkStrategy decides which columns give up width when the table is
+// narrowed to fit a width.
+type ShrinkStrategy struct {
+	widestFirst bool
+	columns     []int
+}
+
+var (
+	// ShrinkProportional narrows every column in proportion to how much
+	// it can shrink. This is the default.
+	ShrinkProportional = ShrinkStrategy{}
+	// ShrinkWidestFirst narrows the widest column, one column of text at a
+	// time, until the table fits.
+	ShrinkWidestFirst = ShrinkStrategy{widestFirst: true}
+)
+
+// ShrinkColumns narrows only the columns cols, each as much as it can before
+// the next, in order.
+func ShrinkColumns(cols ...int) ShrinkStrategy {
+	return ShrinkStrategy{columns: append([]int{}, cols...)}
+}
+
+// SetShrinkStrategy sets which columns give up width first when the table
+// is narrowed to fit a width, such as with SetAutoWidth. Columns are never
+// narrower than their minimum width, whatever the strategy.
+func (t *Table) SetShrinkStrategy(s ShrinkStrategy) {
+	t.shrink = s
+}
+
+// apply narrows the widths by excess, without going below mins, or as much
+// as possible when that is not enough.
+func (s ShrinkStrategy) apply(widths, mins map[int]int, excess int) {
+	switch {
+	case s.columns != nil:
+		for _, y := range s.columns {
+			cut := widths[y] - mins[y]
+			if cut > excess {
+				cut = excess
+			}
+			if cut > 0 {
+				widths[y] -= cut
+				excess -= cut
+			}
+		}
+	case s.widestFirst:
+		for ; excess > 0; excess-- {
+			widest := widestAboveMin(widths, mins)
+			if widest < 0 {
+				return
+			}
+			widths[widest]--
+		}
+	default:
+		slack := 0
+		for y, w := range widths {
+			if w > mins[y] {
+				slack += w - mins[y]
+			}
+		}
+		if excess > slack {
+			excess = slack
+		}
+		if excess <= 0 {
+			return
+		}
+		// Take from each column its share of the excess, then the rest
+		// from the widest columns left after rounding down.
+		left := excess
+		for y := 0; y < len(widths); y++ {
+			if widths[y] <= mins[y] {
+				continue
+			}
+			cut := (widths[y] - mins[y]) * excess / slack
+			widths[y] -= cut
+			left -= cut
+		}
+		for ; left > 0; left-- {
+			widths[widestAboveMin(widths, mins)]--
+		}
+	}
+}
+
+// widestAboveMin returns the first of the widest columns wider than its
+// minimum, or -1 if there is none.
+func widestAboveMin(widths, mins map[int]int) int {
+	widest := -1
+	for y := 0; y < len(widths); y++ {
+		if widths[y] > mins[y] && (widest < 0 || widths[y] > widths[widest]) {
+			widest = y
+		}
+	}
+	return widest
+}
+
+// SetAutoWidth fits the table in the width of the terminal it is rendered
+// to, less margin columns, by narrowing columns as SetShrinkStrategy
+// decides and wrapping their cells again. The width of the terminal is read
+// on every render, so the table follows its resizing. Columns are never
+// narrower than their longest word or their header, and the table is
+// rendered as usual when the output is not a terminal.
+func (t *Table) SetAutoWidth(margin int) {
+	t.autoWidth = true
+	t.autoWidthMargin = margin
+}
+
+// SetAutoFitToWidth fits the table in maxWidth columns, narrowing columns
+// like SetAutoWidth, or in the width of the terminal when maxWidth is 0.
+// With both, the table fits in the narrower width.
+func (t *Table) SetAutoFitToWidth(maxWidth int) {
+	if maxWidth <= 0 {
+		t.SetAutoWidth(0)
+		return
+	}
+	t.maxTableWidth = maxWidth
+}
+
+// autoWidthLimit returns the width the table must fit in when rendered to
+// w, if any.
+func (t *Table) autoWidthLimit(w io.Writer) (int, bool) {
+	limit, fit := t.maxTableWidth, t.maxTableWidth > 0
+	if !t.autoWidth {
+		return limit, fit
+	}
+	width, ok := terminalWidth(w)
+	if !ok {
+		width, ok = terminalWidth(t.out)
+	}
+	if ok && (!fit || width-t.autoWidthMargin < limit) {
+		return width - t.autoWidthMargin, true
+	}
+	return limit, fit
+}
+
+// fitWidth returns a copy of the table laid out with columns narrowed to fit
+// in width, as the shrink strategy decides.
+func (t *Table) fitWidth(width int) *Table {
+	c := t.laidOut()
+	c.autoWidth = false
+	c.maxTableWidth = 0
+	excess := c.getTableWidth() - width
+	if excess <= 0 {
+		return c
+	}
+
+	mins := make(map[int]int)
+	for y := range c.cs {
+		mins[y] = c.minColumnWidth(y)
+	}
+	c.shrink.apply(c.cs, mins, excess)
+
+	// Wrap the cells wider than their column again.
+	for i, columns := range c.lines {
+		for y, cell := range columns {
+			if cellWidth(cell) <= c.cs[y] {
+				continue
+			}
+			cell = c.refit(strings.Join(cell, " "), i, y, c.cs[y])
+			columns[y] = cell
+			if len(cell) > c.rs[i] {
+				c.rs[i] = len(cell)
+			}
+		}
+	}
+	return c
+}
+
+// minColumnWidth returns the narrowest width column y can be wrapped to,
+// the width of its longest word, of its header or set by SetColMinWidth.
+func (t *Table) minColumnWidth(y int) int {
+	min := 1
+	if w := t.colMinWidths[y]; w > min {
+		min = w
+	}
+	if w := t.floorWidths[y]; w > min {
+		min = w
+	}
+	if y < len(t.headers) && len(t.headerSpans) == 0 {
+		for _, h := range t.headers[y] {
+			if w := DisplayWidth(t.formatHeader(y, h)); w > min {
+				min = w
+			}
+		}
+	}
+	cells := make([][]string, 0, len(t.lines)+1)
+	for _, columns := range t.lines {
+		if y < len(columns) {
+			cells = append(cells, columns[y])
+		}
+	}
+	if y < len(t.summary) {
+		cells = append(cells, t.summary[y])
+	}
+	for _, cell := range cells {
+		for _, line := range cell {
+			for _, word := range strings.Split(line, sp) {
+				if w := DisplayWidth(word); w > min {
+					min = w
+				}
+			}
+		}
+	}
+	return min
+}
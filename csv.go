@@ -50,3 +50,66 @@ func NewCSVReader(writer io.Writer, csvReader *csv.Reader, hasHeader bool) (*Tab
 	}
 	return t, nil
 }
+
+// SetCSVSeparator sets the field separator of RenderCSV, such as '\t' for
+// TSV. Default is a comma.
+func (t *Table) SetCSVSeparator(sep rune) {
+	t.csvSeparator = sep
+}
+
+// SetCSVUnmasked writes the original values of the columns masked with
+// SetColumnMask to RenderCSV, instead of the masked values.
+func (t *Table) SetCSVUnmasked(unmasked bool) {
+	t.csvUnmasked = unmasked
+}
+
+// RenderCSV writes the header and the rows to the writer of the table as
+// CSV, quoting the fields that need it. The fields are the values given to
+// the table, before they are formatted or wrapped, without escape
+// sequences, so multi-line cells keep their line breaks. Spacer rows are
+// left out, and a byte order mark is written first when SetBOM is on.
+func (t *Table) RenderCSV() error {
+	return t.renderCSV(t.out)
+}
+
+// renderCSV writes the table to out as CSV, as RenderCSV does.
+func (t *Table) renderCSV(out io.Writer) error {
+	if t.bom {
+		if _, err := io.WriteString(out, BOM); err != nil {
+			return err
+		}
+	}
+	w := csv.NewWriter(out)
+	if t.csvSeparator != 0 {
+		w.Comma = t.csvSeparator
+	}
+	if len(t.headerKeys) > 0 {
+		if err := w.Write(t.csvRecord(t.headerKeys, false)); err != nil {
+			return err
+		}
+	}
+	for i, row := range t.rows {
+		if t.isSpacer(i) {
+			continue
+		}
+		if err := w.Write(t.csvRecord(row, true)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// csvRecord returns the fields of a header or data row, without escape
+// sequences, and masked if data is true and the column is masked.
+func (t *Table) csvRecord(values []string, data bool) []string {
+	record := make([]string, len(values))
+	for y, v := range values {
+		v = ansi.ReplaceAllLiteralString(v, "")
+		if data && !t.csvUnmasked {
+			v = t.maskCell(y, v)
+		}
+		record[y] = v
+	}
+	return record
+}
@@ -1,5 +1,14 @@
 module github.com/liamg/tablewriter
 
-go 1.12
+go 1.18
 
-require github.com/mattn/go-runewidth v0.0.10
+require (
+	github.com/mattn/go-runewidth v0.0.10
+	golang.org/x/term v0.14.0
+	golang.org/x/text v0.13.0
+)
+
+require (
+	github.com/rivo/uniseg v0.1.0 // indirect
+	golang.org/x/sys v0.14.0 // indirect
+)
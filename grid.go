@@ -0,0 +1,119 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+// Grid is the layout of a table, as computed for rendering, for drawing it
+// with other primitives than text, such as those of a TUI framework. It is
+// a snapshot: changing the table afterwards does not change it.
+type Grid struct {
+	widths  []int
+	heights []int
+	header  []GridCell
+	cells   [][]GridCell
+}
+
+// GridCell is a cell of a Grid.
+type GridCell struct {
+	// Lines of the cell, wrapped to the column width.
+	Lines []string
+	// Alignment of the cell, ALIGN_LEFT, ALIGN_RIGHT or ALIGN_CENTER.
+	Align int
+	// Colors given to Rich for the cell, if any.
+	Colors Colors
+}
+
+// Grid returns the layout of the table as it is rendered, with the index
+// column, without the hidden columns and fitted to the width, as set.
+func (t *Table) Grid() *Grid {
+	l := t.rendered(t.out)
+	g := &Grid{widths: make([]int, len(l.cs))}
+	for y := range g.widths {
+		g.widths[y] = l.cs[y]
+	}
+
+	for y, lines := range l.headers {
+		align := l.headerAlignment(y)
+		if align == ALIGN_DEFAULT {
+			align = ALIGN_CENTER
+		}
+		g.header = append(g.header, GridCell{
+			Lines: append([]string(nil), lines...),
+			Align: align,
+		})
+	}
+
+	for i, columns := range l.lines {
+		g.heights = append(g.heights, l.rs[i])
+		row := make([]GridCell, len(columns))
+		for y, cell := range columns {
+			lines := make([]string, len(cell))
+			for x := range cell {
+				lines[x] = l.cellLine(cell, i, y, x)
+			}
+			row[y] = GridCell{
+				Lines:  lines,
+				Align:  l.gridAlignment(i, y, cell),
+				Colors: l.cellColor(i, y),
+			}
+		}
+		g.cells = append(g.cells, row)
+	}
+	return g
+}
+
+// gridAlignment resolves the default alignment of a cell to the one it is
+// printed with.
+func (t *Table) gridAlignment(rowIdx, y int, cell []string) int {
+	if align := t.cellAlignment(rowIdx, y); align != ALIGN_DEFAULT {
+		return align
+	}
+	switch {
+	case t.isBoolCell(y, cell):
+		return ALIGN_CENTER
+	case t.isNumericCell(cell) || t.isHumanizedCell(y, cell):
+		return ALIGN_RIGHT
+	}
+	return ALIGN_LEFT
+}
+
+// Rows returns the number of rows, without the header.
+func (g *Grid) Rows() int {
+	return len(g.cells)
+}
+
+// Columns returns the number of columns.
+func (g *Grid) Columns() int {
+	return len(g.widths)
+}
+
+// ColumnWidths returns the width of each column, without padding.
+func (g *Grid) ColumnWidths() []int {
+	return append([]int(nil), g.widths...)
+}
+
+// RowHeights returns the height of each row, in lines.
+func (g *Grid) RowHeights() []int {
+	return append([]int(nil), g.heights...)
+}
+
+// Header returns the header cell of column col, empty if there is none. Its
+// lines are as set, before any auto formatting.
+func (g *Grid) Header(col int) GridCell {
+	if col < 0 || col >= len(g.header) {
+		return GridCell{}
+	}
+	return g.header[col]
+}
+
+// Cell returns the cell of row and col, empty if there is none.
+func (g *Grid) Cell(row, col int) GridCell {
+	if row < 0 || row >= len(g.cells) || col < 0 || col >= len(g.cells[row]) {
+		return GridCell{}
+	}
+	return g.cells[row][col]
+}
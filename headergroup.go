@@ -0,0 +1,71 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+// HeaderCell is a cell of the header row set with SetHeaderSpan, labeling
+// Span columns.
+type HeaderCell struct {
+	Text string
+	Span int
+}
+
+// SetHeaderSpan adds a row above the header set with SetHeader, for a
+// header of two levels, such as a year over the months of its columns. Each
+// cell covers the next Span columns, or one when Span is less than 1, with
+// its text centered over their combined width and separators, and the line
+// beneath separating only these cells. Columns not covered are left blank.
+// Unlike SetHeaderSpans, which makes the header cells themselves span
+// columns, the header keeps one cell per column. Columns are widened, the
+// last of each cell, when the text of a cell is wider than them. The row is
+// printed above the header only, not with the header repeated below the
+// rows by SetHeaderAtBottom.
+func (t *Table) SetHeaderSpan(cells []HeaderCell) {
+	t.headerGroups = append([]HeaderCell(nil), cells...)
+}
+
+// groupHeading returns a copy of the table whose header is the row set with
+// SetHeaderSpan, spanning its columns, to print it and the lines around it,
+// or nil when there is none. The copy shares the column widths of t.
+func (t *Table) groupHeading() *Table {
+	if len(t.headerGroups) == 0 || len(t.headers) == 0 {
+		return nil
+	}
+	g := *t
+	g.headers = make([][]string, len(t.headerGroups))
+	g.headerSpans = make([]int, len(t.headerGroups))
+	g.headerParams = nil
+	g.headerVertical = false
+	height := 1
+	for i, cell := range t.headerGroups {
+		g.headerSpans[i] = cell.Span
+		y, _ := g.headerSpan(i)
+		g.headers[i], _ = g.cellLines(cell.Text, y, headerRowIdx)
+		if len(g.headers[i]) > height {
+			height = len(g.headers[i])
+		}
+	}
+	g.rs = map[int]int{headerRowIdx: height}
+	return &g
+}
+
+// allHeaderGroups returns the cells set with SetHeaderSpan, followed by a
+// blank cell for each column they do not cover.
+func (t *Table) allHeaderGroups() []HeaderCell {
+	cells := append([]HeaderCell(nil), t.headerGroups...)
+	covered := 0
+	for i := range cells {
+		if cells[i].Span < 1 {
+			cells[i].Span = 1
+		}
+		covered += cells[i].Span
+	}
+	for ; covered < len(t.cs); covered++ {
+		cells = append(cells, HeaderCell{Span: 1})
+	}
+	return cells
+}
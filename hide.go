@@ -0,0 +1,232 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+// SetHideEmptyColumns hides, when rendering, the columns without any text in
+// the header nor in any row, such as the fields of sparse structs that are
+// never set. The other columns keep their settings. Columns with a summary
+// are never hidden, nor are any columns when the header has spans or when
+// every column is empty.
+func (t *Table) SetHideEmptyColumns(hide bool) {
+	t.hideEmptyColumns = hide
+}
+
+// emptyColumns returns the columns hidden by SetHideEmptyColumns.
+func (t *Table) emptyColumns() map[int]bool {
+	if !t.hideEmptyColumns || len(t.headerSpans) > 0 || len(t.headerGroups) > 0 {
+		return nil
+	}
+	empty := make(map[int]bool)
+	for y := 0; y < len(t.cs); y++ {
+		if t.isEmptyColumn(y) {
+			empty[y] = true
+		}
+	}
+	if len(empty) == len(t.cs) {
+		return nil
+	}
+	return empty
+}
+
+// isEmptyColumn reports whether column y has no text in the header, the
+// footer nor in any row, and no summary.
+func (t *Table) isEmptyColumn(y int) bool {
+	if y < len(t.summaries) && t.summaries[y] != SummaryNone {
+		return false
+	}
+	if y < len(t.headers) && !isBlank(t.headers[y]) {
+		return false
+	}
+	if y < len(t.footers) && t.footers[y] != "" {
+		return false
+	}
+	for _, row := range t.lines {
+		if y < len(row) && !isBlank(row[y]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBlank reports whether all the lines of a cell are empty.
+func isBlank(lines []string) bool {
+	for _, line := range lines {
+		if line != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutColumns returns a copy of the table to render without the hidden
+// columns, with the settings of the other columns moved to their new index.
+func (t *Table) withoutColumns(hidden map[int]bool) *Table {
+	index := make(map[int]int)
+	for y := 0; y < len(t.cs); y++ {
+		if !hidden[y] {
+			index[y] = len(index)
+		}
+	}
+	c := t.moveColumns(index)
+	c.hideEmptyColumns = false
+	return c
+}
+
+// moveColumns returns a copy of the table with column y moved to index[y],
+// along with its settings, and without the columns missing from index.
+func (t *Table) moveColumns(index map[int]int) *Table {
+	c := *t
+	c.cs = make(map[int]int)
+	for y, v := range t.cs {
+		if n, ok := index[y]; ok {
+			c.cs[n] = v
+		}
+	}
+	c.rs = make(map[int]int)
+	for i, v := range t.rs {
+		c.rs[i] = v
+	}
+
+	c.lines = make([][][]string, len(t.lines))
+	for i, row := range t.lines {
+		for y, cell := range row {
+			if n, ok := index[y]; ok {
+				for len(c.lines[i]) <= n {
+					c.lines[i] = append(c.lines[i], nil)
+				}
+				c.lines[i][n] = cell
+			}
+		}
+	}
+	if len(t.headerSpans) == 0 {
+		c.headers = nil
+		for y, cell := range t.headers {
+			if n, ok := index[y]; ok {
+				for len(c.headers) <= n {
+					c.headers = append(c.headers, nil)
+				}
+				c.headers[n] = cell
+			}
+		}
+	}
+	c.cellColors = make(map[int][]Colors)
+	for i, colors := range t.cellColors {
+		for y, color := range colors {
+			if n, ok := index[y]; ok {
+				for len(c.cellColors[i]) <= n {
+					c.cellColors[i] = append(c.cellColors[i], nil)
+				}
+				c.cellColors[i][n] = color
+			}
+		}
+	}
+
+	c.columnColors = make(map[int]Colors)
+	for y, colors := range t.columnColors {
+		if n, ok := index[y]; ok {
+			c.columnColors[n] = colors
+		}
+	}
+
+	c.footers = moveStrings(t.footers, index)
+	c.headerParams = moveStrings(t.headerParams, index)
+	c.columnsParams = moveStrings(t.columnsParams, index)
+	c.columnsAlign = nil
+	for y, align := range t.columnsAlign {
+		if n, ok := index[y]; ok {
+			for len(c.columnsAlign) <= n {
+				c.columnsAlign = append(c.columnsAlign, t.align)
+			}
+			c.columnsAlign[n] = align
+		}
+	}
+	c.summaries = nil
+	for y, s := range t.summaries {
+		if n, ok := index[y]; ok {
+			for len(c.summaries) <= n {
+				c.summaries = append(c.summaries, SummaryNone)
+			}
+			c.summaries[n] = s
+		}
+	}
+
+	c.columnsAlignMap = remapInts(t.columnsAlignMap, index)
+	c.colMinWidths = remapInts(t.colMinWidths, index)
+	c.floorWidths = remapInts(t.floorWidths, index)
+	c.colMaxWidths = remapInts(t.colMaxWidths, index)
+	c.sectionAlign = make(map[Section]map[int]int)
+	for section, aligns := range t.sectionAlign {
+		c.sectionAlign[section] = remapInts(aligns, index)
+	}
+	// Without columns, every column is merged.
+	if t.columnsToAutoMergeCells != nil {
+		c.columnsToAutoMergeCells = make(map[int]bool)
+		for y, merge := range t.columnsToAutoMergeCells {
+			if n, ok := index[y]; ok {
+				c.columnsToAutoMergeCells[n] = merge
+			}
+		}
+	}
+	c.columnOverflow = make(map[int]Overflow)
+	for y, overflow := range t.columnOverflow {
+		if n, ok := index[y]; ok {
+			c.columnOverflow[n] = overflow
+		}
+	}
+	c.columnBools = make(map[int][2]string)
+	for y, glyphs := range t.columnBools {
+		if n, ok := index[y]; ok {
+			c.columnBools[n] = glyphs
+		}
+	}
+	c.columnHumanize = make(map[int]Humanize)
+	for y, h := range t.columnHumanize {
+		if n, ok := index[y]; ok {
+			c.columnHumanize[n] = h
+		}
+	}
+	c.colPercentile = make(map[int]float64)
+	for y, p := range t.colPercentile {
+		if n, ok := index[y]; ok {
+			c.colPercentile[n] = p
+		}
+	}
+	if n, ok := index[t.groupCol]; ok {
+		c.groupCol = n
+	} else {
+		c.groupCol = -1
+	}
+	return &c
+}
+
+// moveStrings returns the values of the columns in index, at their new
+// index.
+func moveStrings(values []string, index map[int]int) []string {
+	var moved []string
+	for y, v := range values {
+		if n, ok := index[y]; ok {
+			for len(moved) <= n {
+				moved = append(moved, "")
+			}
+			moved[n] = v
+		}
+	}
+	return moved
+}
+
+// remapInts returns m with its column keys moved to their new index,
+// without the columns missing from index.
+func remapInts(m map[int]int, index map[int]int) map[int]int {
+	remapped := make(map[int]int)
+	for y, v := range m {
+		if n, ok := index[y]; ok {
+			remapped[n] = v
+		}
+	}
+	return remapped
+}
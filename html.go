@@ -0,0 +1,91 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// RenderHTML writes the header and the rows to the writer of the table as
+// an HTML table, with the header in a thead and the rows in a tbody. Cells
+// hold the values given to the table, before they are formatted or wrapped,
+// without escape sequences and HTML-escaped, and the lines of multi-line
+// cells are joined with <br>. Cells aligned left, right or center get the
+// alignment as an inline style, numbers right as in the text table. Header
+// cells get the alignment of the header, as set by SetHeaderAlignment, and
+// header cells spanning columns none, as they are centered. Spacer rows are
+// left out.
+func (t *Table) RenderHTML() error {
+	return t.renderHTML(t.out)
+}
+
+// renderHTML writes the table to w as HTML, as RenderHTML does.
+func (t *Table) renderHTML(w io.Writer) error {
+	// The alignment of the columns is resolved once laid out
+	t = t.laidOut()
+	var b strings.Builder
+	b.WriteString("<table>\n")
+	if len(t.headerKeys) > 0 {
+		b.WriteString("<thead>\n<tr>")
+		for i, h := range t.headerKeys {
+			y, n := t.headerSpan(i)
+			b.WriteString("<th")
+			if n > 1 {
+				fmt.Fprintf(&b, ` colspan="%d"`, n)
+			} else {
+				b.WriteString(htmlStyle(t.headerAlignment(y)))
+			}
+			b.WriteString(">" + htmlCell(h) + "</th>")
+		}
+		b.WriteString("</tr>\n</thead>\n")
+	}
+	b.WriteString("<tbody>\n")
+	for i, row := range t.rows {
+		if t.isSpacer(i) {
+			continue
+		}
+		b.WriteString("<tr>")
+		for y, v := range row {
+			align := t.cellAlignment(i, y)
+			if align == ALIGN_DEFAULT && t.isNumericCell([]string{v}) {
+				align = ALIGN_RIGHT
+			}
+			b.WriteString("<td" + htmlStyle(align) + ">" + htmlCell(t.maskCell(y, v)) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// htmlStyle returns the style attribute of a cell with the given alignment,
+// if any.
+func htmlStyle(align int) string {
+	switch align {
+	case ALIGN_LEFT:
+		return ` style="text-align:left"`
+	case ALIGN_CENTER:
+		return ` style="text-align:center"`
+	case ALIGN_RIGHT:
+		return ` style="text-align:right"`
+	}
+	return ""
+}
+
+// htmlCell returns the HTML of a cell holding s.
+func htmlCell(s string) string {
+	lines := getLines(ansi.ReplaceAllLiteralString(s, ""))
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	return strings.Join(lines, "<br>")
+}
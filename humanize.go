@@ -0,0 +1,90 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Humanize selects how SetColumnHumanize shortens numbers.
+type Humanize int
+
+const (
+	// HumanizeSI uses powers of 1000 with the suffixes K, M, G, T, P and E.
+	HumanizeSI Humanize = iota
+	// HumanizeBytes uses powers of 1024 with the units B, KiB, MiB, GiB,
+	// TiB, PiB and EiB.
+	HumanizeBytes
+)
+
+var (
+	humanized = regexp.MustCompile(`^-?[\d,]+(?:\.\d+)?(?:[KMGTPE]| (?:[KMGTPE]i)?B)$`)
+	siUnits   = []string{"", "K", "M", "G", "T", "P", "E"}
+	byteUnits = []string{" B", " KiB", " MiB", " GiB", " TiB", " PiB", " EiB"}
+)
+
+// SetColumnHumanize shortens the numbers of the data cells of the column,
+// such as 1536 to "1.5K" or 1048576 to "1.0 MiB", before their width is
+// measured. Numbers are divided by the base until they are below it, and
+// rounded to one decimal, moving to the next unit when the rounding reaches
+// the base. Numbers below the base are left unchanged with HumanizeSI, and
+// only get the B unit with HumanizeBytes. Cells that are not numbers are
+// left unchanged, and the original values of all cells are kept.
+func (t *Table) SetColumnHumanize(col int, h Humanize) {
+	if t.columnHumanize == nil {
+		t.columnHumanize = make(map[int]Humanize)
+	}
+	t.columnHumanize[col] = h
+}
+
+// isHumanizedCell reports whether a cell of column y holds a number
+// shortened by SetColumnHumanize, aligned right like numbers.
+func (t *Table) isHumanizedCell(y int, cell []string) bool {
+	if _, ok := t.columnHumanize[y]; !ok || !t.autoAlignNum || len(cell) != 1 {
+		return false
+	}
+	return humanized.MatchString(strings.TrimSpace(cell[0]))
+}
+
+// humanize shortens str if it is a number and column y is humanized.
+func (t *Table) humanize(y int, str string) string {
+	h, ok := t.columnHumanize[y]
+	if !ok {
+		return str
+	}
+	value := strings.TrimSpace(str)
+	if !t.isNumeric(value) {
+		return str
+	}
+	f, err := strconv.ParseFloat(strings.Replace(value, ",", "", -1), 64)
+	if err != nil {
+		return str
+	}
+
+	base, units := 1000.0, siUnits
+	if h == HumanizeBytes {
+		base, units = 1024.0, byteUnits
+	}
+	if math.Abs(f) < base {
+		return value + units[0]
+	}
+	unit := 0
+	for math.Abs(f) >= base && unit < len(units)-1 {
+		f /= base
+		unit++
+	}
+	s := strconv.FormatFloat(f, 'f', 1, 64)
+	if r, _ := strconv.ParseFloat(s, 64); math.Abs(r) >= base && unit < len(units)-1 {
+		s = strconv.FormatFloat(f/base, 'f', 1, 64)
+		unit++
+	}
+	return s + units[unit]
+}
@@ -0,0 +1,84 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// SetKVFlatten enables / disables flattening the nested structs of the value
+// given to RenderKV into one row per field, named with dotted keys such as
+// "Address.City". Nested structs with a String method are never flattened.
+func (t *Table) SetKVFlatten(flatten bool) {
+	t.kvFlatten = flatten
+}
+
+// RenderKV renders a single struct, or pointer to struct, as a two-column
+// table of field names and values, without header. Names and values follow
+// the rules of SetStructs: the tablewriter tag names the field, and values
+// are printed with their String method if they have one. Fields tagged "-"
+// and unexported fields are skipped. The names are aligned like the first column, so
+// SetColumnAlignment can align them right.
+func (t *Table) RenderKV(v interface{}) error {
+	if v == nil {
+		return errors.New("nil value")
+	}
+	vv := reflect.Indirect(reflect.ValueOf(v))
+	if !vv.IsValid() {
+		return errors.New("nil value")
+	}
+	if vv.Kind() != reflect.Struct {
+		return fmt.Errorf("invalid kind %s", vv.Kind())
+	}
+	rows, err := t.kvRows(vv, "")
+	if err != nil {
+		return err
+	}
+	t.AppendBulk(rows)
+	t.Render()
+	return nil
+}
+
+// kvRows returns the name and value of each field of the struct v, with
+// the names prefixed by prefix.
+func (t *Table) kvRows(v reflect.Value, prefix string) ([][]string, error) {
+	var rows [][]string
+	e := v.Type()
+	for i := 0; i < e.NumField(); i++ {
+		f := e.Field(i)
+		tag := f.Tag.Get("tablewriter")
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		c, err := parseTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", f.Name, err)
+		}
+		name := c.header
+		if name == "" {
+			name = f.Name
+		}
+		name = prefix + name
+
+		fv := reflect.Indirect(v.Field(i))
+		if t.kvFlatten && fv.Kind() == reflect.Struct {
+			if _, ok := fv.Interface().(fmt.Stringer); !ok {
+				nested, err := t.kvRows(fv, name+".")
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, nested...)
+				continue
+			}
+		}
+		rows = append(rows, []string{name, fieldText(v.Field(i))})
+	}
+	return rows, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"io"
+)
+
+// Format identifies how a table is written to an output.
+type Format int
+
+const (
+	// FormatText is the bordered text table written by Render.
+	FormatText Format = iota
+	// FormatCSV is the header and the rows as CSV.
+	FormatCSV
+	// FormatHTML is the header and the rows as an HTML table.
+	FormatHTML
+)
+
+type output struct {
+	w      io.Writer
+	format Format
+}
+
+// AddOutput registers an additional writer that Render writes the table to
+// in the given format. Outputs sharing a format are written in a single pass
+// over the rows. An output that fails to be written is left as is, and does
+// not stop the table or the other outputs from being written.
+func (t *Table) AddOutput(w io.Writer, format Format) {
+	t.outputs = append(t.outputs, output{w: w, format: format})
+}
+
+// outputWriters returns the registered writers for the given format.
+func (t *Table) outputWriters(format Format) []io.Writer {
+	var writers []io.Writer
+	for _, o := range t.outputs {
+		if o.format == format {
+			writers = append(writers, &quietWriter{w: o.w})
+		}
+	}
+	return writers
+}
+
+// renderOutputs writes the table to the outputs in the formats other than
+// text, each format through its renderer once for all its outputs.
+func (t *Table) renderOutputs() {
+	if writers := t.outputWriters(FormatCSV); len(writers) > 0 {
+		t.renderCSV(io.MultiWriter(writers...))
+	}
+	if writers := t.outputWriters(FormatHTML); len(writers) > 0 {
+		t.renderHTML(io.MultiWriter(writers...))
+	}
+}
+
+// errWriter remembers the first error of w and stops writing after it.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	e.err = err
+	return n, err
+}
+
+// quietWriter stops writing to w after its first error, which it does not
+// report, so that writers it shares an io.MultiWriter with keep going.
+type quietWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (q *quietWriter) Write(p []byte) (int, error) {
+	if q.err == nil {
+		_, q.err = q.w.Write(p)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"io"
+)
+
+// PAGE_BREAK separates the pages written by RenderPaged.
+const PAGE_BREAK = "\f"
+
+// SetContinuedMarker sets a line, such as "(continued)", printed below every
+// page written by RenderPaged but the last. No line is printed when empty.
+func (t *Table) SetContinuedMarker(marker string) {
+	t.continued = marker
+}
+
+// RenderPaged writes the table to w as pages of at most pageHeight lines,
+// separated by a form feed. Every page is a complete table, with its borders
+// and heading, holding as many rows as fit. Rows are never split across
+// pages, so a row too tall for a page on its own is an error. The summary
+// row and the color legend are printed after the rows of the last page, and
+// are not counted in its height. The first error writing to w is returned.
+// The table is left unchanged.
+func (t *Table) RenderPaged(w io.Writer, pageHeight int) error {
+	// Pages are sized and printed on the table as printed, fitted and with
+	// its columns hidden or added, as its rows may wrap to more lines.
+	ew := &errWriter{w: w}
+	l := t.rendered(ew)
+	rows := l.visibleRows()
+
+	for start := 0; start == 0 || start < len(rows); {
+		n := l.pageSize(rows[start:], pageHeight)
+		l.morePages = start+n < len(rows)
+		if l.morePages && t.continued != "" {
+			n = l.pageSize(rows[start:], pageHeight-1)
+		}
+		if n == 0 && len(rows) > 0 {
+			return fmt.Errorf("row %d does not fit in a page of %d lines", rows[start], pageHeight)
+		}
+
+		if start > 0 {
+			io.WriteString(ew, PAGE_BREAK)
+		}
+		l.pageRows = rows[start : start+n]
+		l.writeText(ew)
+		if l.morePages && t.continued != "" {
+			io.WriteString(ew, t.continued+t.lineEnd())
+		}
+		l.bom = false
+		if ew.err != nil || !l.morePages {
+			break
+		}
+		start += n
+	}
+	return ew.err
+}
+
+// pageSize returns how many of rows, from the first, fit in a page of
+// height lines.
+func (t *Table) pageSize(rows []int, height int) int {
+	used := 0
+	if t.borders.Top {
+		used++
+	}
+	if t.borders.Bottom {
+		used++
+	}
+	if len(t.headers) > 0 {
+		heading := t.rs[headerRowIdx]
+		if t.hdrLine {
+			heading++
+		}
+		used += heading
+		if t.hasBottomHeading() {
+			used += heading
+		}
+	}
+	// The row of header groups and the line beneath
+	if g := t.groupHeading(); g != nil {
+		used += g.rs[headerRowIdx] + 1
+	}
+
+	n := 0
+	for k, i := range rows {
+		h := t.rs[i]
+		if k > 0 && (t.rowLine || t.isNewGroup(rows[k-1], i)) {
+			h++
+		}
+		if used+h > height {
+			break
+		}
+		used += h
+		n++
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+// markdownBorderStyle draws the borders of PresetMarkdown, whose rule under
+// the header is joined with | as Markdown requires, without any escape
+// sequence
+var markdownBorderStyle = BorderStyle{
+	CenterAll: "|",
+	CenterNES: "|",
+	CenterNSW: "|",
+	CenterNEW: "|",
+	CenterESW: "|",
+	CenterNE:  "|",
+	CenterWN:  "|",
+	CenterSW:  "|",
+	CenterES:  "|",
+	Row:       "-",
+	Column:    "|",
+}
+
+// Preset names a bundle of layout options mimicking a well known tool.
+type Preset int
+
+const (
+	// PresetPSQL looks like the aligned output of psql: no outer border,
+	// column separators, a rule under centered headers and no header
+	// formatting.
+	PresetPSQL Preset = iota
+	// PresetMySQL looks like the mysql client: a full border, a rule under
+	// left aligned headers and no header formatting.
+	PresetMySQL
+	// PresetMarkdown is a GitHub Flavored Markdown table: left and right
+	// borders only, drawn with | and -, a rule under left aligned headers
+	// and no header formatting, colors or escape sequences.
+	PresetMarkdown
+	// PresetBorderless looks like `column -t`: no borders or rules, cells
+	// separated by two spaces and no header formatting.
+	PresetBorderless
+	// PresetCompact is the tightest layout: no borders or rules, cells
+	// separated by a single space and formatted headers.
+	PresetCompact
+)
+
+// SetPreset configures the table to look like the named preset.
+//
+// Every preset sets exactly the following options, but those marked -,
+// leaving everything else (column widths, data alignment, cell colors)
+// untouched:
+//
+//	Option                  PSQL    MySQL   Markdown  Borderless  Compact
+//	SetBorders (L,R,T,B)    none    all     L,R       none        none
+//	SetHeaderLine           true    true    true      false       false
+//	SetRowLine              false   false   false     false       false
+//	SetAutoMergeCells       false   false   false     false       false
+//	SetAutoFormatHeaders    false   false   false     false       true
+//	SetHeaderAlignment      center  left    left      left        left
+//	SetNoWhiteSpace         false   false   false     true        true
+//	SetTablePadding         ""      ""      ""        "  "        " "
+//	SetTrailingSpace        true    true    true      false       false
+//	SetBorderStyle          -       -       | and -   -           -
+//	SetHeaderStyle          -       -       false     -           -
+//	SetHeaderColor          -       -       none      -           -
+//
+// Unknown presets are ignored.
+func (t *Table) SetPreset(p Preset) {
+	switch p {
+	case PresetPSQL:
+		t.applyPreset(Border{}, true, false, ALIGN_CENTER, false, "", true)
+	case PresetMySQL:
+		t.applyPreset(Border{Left: true, Right: true, Top: true, Bottom: true}, true, false, ALIGN_LEFT, false, "", true)
+	case PresetMarkdown:
+		t.applyPreset(Border{Left: true, Right: true}, true, false, ALIGN_LEFT, false, "", true)
+		t.SetBorderStyle(markdownBorderStyle)
+		t.SetHeaderStyle(false)
+		t.headerParams = nil
+	case PresetBorderless:
+		t.applyPreset(Border{}, false, false, ALIGN_LEFT, true, "  ", false)
+	case PresetCompact:
+		t.applyPreset(Border{}, false, true, ALIGN_LEFT, true, " ", false)
+	}
+}
+
+func (t *Table) applyPreset(border Border, hdrLine, autoFmt bool, hAlign int, noWhiteSpace bool, padding string, trailingSpace bool) {
+	t.SetBorders(border)
+	t.SetHeaderLine(hdrLine)
+	t.SetRowLine(false)
+	t.SetAutoMergeCells(false)
+	t.SetAutoFormatHeaders(autoFmt)
+	t.SetHeaderAlignment(hAlign)
+	t.SetNoWhiteSpace(noWhiteSpace)
+	t.SetTablePadding(padding)
+	t.SetTrailingSpace(trailingSpace)
+}
+
+// RenderAligned renders the table as plain aligned columns, without any
+// borders or lines, with a single space between cells and no white space
+// at the end of the lines. Headers are printed as given, without header
+// formatting or colors. The table is written to the writer of the table
+// only, not to the outputs added with AddOutput, and the first error
+// writing it is returned. The settings of the table are left unchanged.
+func (t *Table) RenderAligned() error {
+	l := t.laidOut()
+	l.SetBorders(Border{})
+	l.SetHeaderLine(false)
+	l.SetRowLine(false)
+	l.SetAutoMergeCells(false)
+	l.SetNoWhiteSpace(true)
+	l.SetTablePadding(" ")
+	l.SetAutoFormatHeaders(false)
+	l.SetHeaderStyle(false)
+	l.SetGroupHeaderStyle(false)
+	l.SetTrailingSpace(false)
+	l.headerParams = nil
+
+	ew := &errWriter{w: t.out}
+	l.renderTo(ew)
+	return ew.err
+}
@@ -0,0 +1,48 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+// SetRTL lays the columns out from right to left when rendering, for
+// right-to-left scripts such as Arabic or Hebrew: the first column is
+// printed rightmost, with its settings, and cells without an alignment are
+// aligned right, or left for numbers. The borders follow the columns, so
+// the corners and junctions are mirrored with them. Columns keep their
+// index for every setting.
+func (t *Table) SetRTL(rtl bool) {
+	t.rtl = rtl
+}
+
+// mirroredColumns returns a copy of the table to render with its columns in
+// reverse order.
+func (t *Table) mirroredColumns() *Table {
+	n := len(t.cs)
+	index := make(map[int]int, n)
+	for y := 0; y < n; y++ {
+		index[y] = n - 1 - y
+	}
+	c := t.moveColumns(index)
+	c.mirrored = true
+
+	if len(t.headerSpans) > 0 {
+		c.headers = make([][]string, len(t.headers))
+		c.headerSpans = make([]int, len(t.headers))
+		for i, cell := range t.headers {
+			k := len(t.headers) - 1 - i
+			c.headers[k] = cell
+			_, c.headerSpans[k] = t.headerSpan(i)
+		}
+	}
+	if len(t.headerGroups) > 0 {
+		groups := t.allHeaderGroups()
+		c.headerGroups = make([]HeaderCell, len(groups))
+		for i, cell := range groups {
+			c.headerGroups[len(groups)-1-i] = cell
+		}
+	}
+	return c
+}
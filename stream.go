@@ -0,0 +1,147 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SetStreamWidths sets the widths of the columns of a streamed table, which
+// cannot be computed from rows that are not there yet. Columns keep the
+// width of their header when it is wider. The widths set by SetColMinWidth
+// are used when this is not called.
+func (t *Table) SetStreamWidths(widths []int) {
+	t.streamWidths = append([]int(nil), widths...)
+}
+
+// StartStream starts rendering the table one row at a time, without keeping
+// the rows in memory: it prints the top border and the header right away,
+// then every row passed to StreamRow as it comes, until EndStream prints
+// the bottom border. The columns have the widths set by SetStreamWidths or
+// SetColMinWidth, and an error is returned when there are none. Settings
+// that need every row, such as the summary row, merged cells or auto
+// fitting the width, do not apply to streamed rows, nor do the ones that
+// need the whole text, such as SetOutputFilter and SetCodeFence. The table
+// is streamed from a copy, and is left unchanged.
+func (t *Table) StartStream() error {
+	s := *t
+	s.cs = make(map[int]int, len(t.cs))
+	for y, w := range t.cs {
+		s.cs[y] = w
+	}
+	for y, w := range t.streamWidths {
+		if w > s.cs[y] {
+			s.cs[y] = w
+		}
+	}
+	if len(s.cs) == 0 {
+		return errors.New("no column widths to stream the table with")
+	}
+	for y := 0; y < len(s.cs); y++ {
+		if _, ok := s.cs[y]; !ok {
+			return fmt.Errorf("no width for column %d to stream the table with", y)
+		}
+	}
+	s.rs = make(map[int]int, len(t.rs))
+	for i, h := range t.rs {
+		s.rs[i] = h
+	}
+	s.columnsAlign = append([]int(nil), t.columnsAlign...)
+	s.fitHeaders()
+	s.fillAlignment(len(s.cs))
+	s.glyphEscaper = s.style.escaper()
+	s.streamRows = 0
+	t.stream = &s
+
+	if s.bom {
+		fmt.Fprint(s.out, BOM)
+	}
+	if s.borders.Top {
+		s.printTopLine()
+	}
+	s.printHeading()
+	return nil
+}
+
+// StreamRow prints row right away as the next row of a table started with
+// StartStream. Cells wider than their column are wrapped, and words that
+// still do not fit are truncated. Cells past the last column are dropped.
+// The hook set by SetRowRenderedHook is called with the index of the row
+// in the stream once printed.
+func (t *Table) StreamRow(row []string) {
+	if t.stream == nil {
+		return
+	}
+	t.stream.streamRow(row)
+}
+
+// streamRow prints row as the next row of the stream t.
+func (t *Table) streamRow(row []string) {
+	i := t.streamRows
+	columns := make([][]string, len(t.cs))
+	t.rs[i] = 1
+	for y := range columns {
+		value := ""
+		if y < len(row) {
+			value = row[y]
+		}
+		cell := t.streamCell(value, i, y)
+		if len(cell) > t.rs[i] {
+			t.rs[i] = len(cell)
+		}
+		columns[y] = cell
+	}
+
+	// The line between two rows is printed before the second, as the last
+	// row is not known until EndStream.
+	if t.rowLine && i > 0 {
+		t.printLine(true, false, false)
+	}
+	rowLine := t.rowLine
+	t.rowLine = false
+	t.printRow(columns, i, false)
+	t.rowLine = rowLine
+	if t.rowRenderedHook != nil {
+		t.rowRenderedHook(i)
+	}
+
+	delete(t.rs, i)
+	t.streamRows++
+}
+
+// streamCell returns the lines of the cell of column y of streamed row i,
+// fitted to the width of the column.
+func (t *Table) streamCell(value string, i, y int) []string {
+	width := t.cs[y]
+	cell, w := t.cellLines(value, y, i)
+	if w <= width {
+		return cell
+	}
+	cell = t.refit(strings.Join(cell, sp), i, y, width)
+	for k, line := range cell {
+		cell[k] = truncate(line, width, ELLIPSIS)
+	}
+	return cell
+}
+
+// EndStream prints the bottom border of a table started with StartStream,
+// and its caption and legend, if any.
+func (t *Table) EndStream() {
+	s := t.stream
+	if s == nil {
+		return
+	}
+	t.stream = nil
+	if s.borders.Bottom {
+		s.printSpanLine(true, false, true, false, false)
+	}
+	s.printCaption()
+	s.printLegend()
+}
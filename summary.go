@@ -0,0 +1,116 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Summary is the value computed for a column of the summary row.
+type Summary int
+
+const (
+	// SummaryNone leaves the cell of the summary row blank.
+	SummaryNone Summary = iota
+	// SummaryCount counts the non-empty cells of the column.
+	SummaryCount
+	// SummaryUniques counts the distinct non-empty cells of the column.
+	SummaryUniques
+	// SummarySum adds up the cells of the column, which must be numbers.
+	SummarySum
+)
+
+// SetSummaryRow adds a row below the rows, separated from them by a line,
+// holding the summary of each column computed from the rows at render.
+// Columns without a summary, and sums of columns holding anything other
+// than numbers, are left blank.
+func (t *Table) SetSummaryRow(summaries ...Summary) {
+	t.summaries = summaries
+}
+
+// hasSummary reports whether the summary row is printed, which is also the
+// footer row.
+func (t *Table) hasSummary() bool {
+	if t.morePages {
+		return false
+	}
+	return len(t.footers) > 0 || len(t.summaries) > 0 && len(t.lines) > 0
+}
+
+// computeSummary parses the cells of the summary row from the footer and
+// the rows. Cells of the footer left empty get the summary of their column.
+func (t *Table) computeSummary() {
+	t.summary = nil
+	if !t.hasSummary() {
+		return
+	}
+	n := len(t.cs)
+	if len(t.footers) > n {
+		n = len(t.footers)
+	}
+	for y := 0; y < n; y++ {
+		s := SummaryNone
+		if y < len(t.summaries) {
+			s = t.summaries[y]
+		}
+		value := ""
+		if y < len(t.footers) {
+			value = t.footers[y]
+		}
+		if value == "" {
+			value = t.summarize(y, s)
+		}
+		t.summary = append(t.summary, t.parseDimension(value, y, footerRowIdx))
+	}
+}
+
+// summarize computes the summary s of column y.
+func (t *Table) summarize(y int, s Summary) string {
+	var values []string
+	for i := range t.lines {
+		if v := t.cellText(i, y); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	switch s {
+	case SummaryCount:
+		return strconv.Itoa(len(values))
+	case SummaryUniques:
+		seen := make(map[string]bool)
+		for _, v := range values {
+			seen[v] = true
+		}
+		return strconv.Itoa(len(seen))
+	case SummarySum:
+		sum := 0.0
+		for _, v := range values {
+			v = strings.TrimSpace(v)
+			if !t.isNumeric(v) {
+				return ""
+			}
+			f, err := strconv.ParseFloat(strings.Replace(v, ",", "", -1), 64)
+			if err != nil {
+				return ""
+			}
+			sum += f
+		}
+		return strconv.FormatFloat(sum, 'f', -1, 64)
+	}
+	return ""
+}
+
+// printSummary prints the summary row, or footer, below the rows.
+func (t *Table) printSummary() {
+	// A row line already separates the last row
+	if !t.rowLine {
+		t.printLine(true, false, false)
+	}
+	t.printRow(t.summary, footerRowIdx, !t.hasBottomHeading())
+}
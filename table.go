@@ -13,9 +13,15 @@ import (
     "errors"
     "fmt"
     "io"
+    "math"
+    "os"
     "reflect"
     "regexp"
+    "sort"
+    "strconv"
     "strings"
+
+    "golang.org/x/text/language"
 )
 
 const (
@@ -36,6 +42,9 @@ const (
     COLUMN     = "\x1b[2m│\x1b[0m"
     SPACE      = " "
     NEWLINE    = "\n"
+    BOM        = "\xef\xbb\xbf"
+    ELLIPSIS   = "…"
+    CLEAR_EOL  = "\x1b[K"
 )
 
 const (
@@ -48,6 +57,14 @@ const (
 var (
     decimal = regexp.MustCompile(`^-?(?:\d{1,3}(?:,\d{3})*|\d+)(?:\.\d+)?$`)
     percent = regexp.MustCompile(`^-?\d+\.?\d*%$`)
+    verb    = regexp.MustCompile(`%(-?)(\d*)[sv]`)
+
+    // ASCII lookalikes of the box drawing glyphs of the borders
+    asciiGlyphs = map[string]string{
+        "│": "|", "─": "-",
+        "┌": "+", "┐": "+", "└": "+", "┘": "+",
+        "├": "+", "┤": "+", "┬": "+", "┴": "+", "┼": "+",
+    }
 )
 
 type Border struct {
@@ -57,6 +74,102 @@ type Border struct {
     Bottom bool
 }
 
+// BorderStyle holds the glyphs the lines and the column separators are
+// drawn with. The junctions are named after the directions of the
+// segments joining there, such as CenterNES for the left end of a line
+// between rows, joined from the north, east and south.
+type BorderStyle struct {
+    CenterAll string
+    CenterNES string
+    CenterNSW string
+    CenterNEW string
+    CenterESW string
+    CenterNE  string
+    CenterWN  string
+    CenterSW  string
+    CenterES  string
+    Row       string
+    Column    string
+    // Draw the borders in the dim color
+    Dim bool
+}
+
+var (
+    // DefaultBorderStyle draws dim box drawing lines
+    DefaultBorderStyle = BorderStyle{
+        CenterAll: "┼",
+        CenterNES: "├",
+        CenterNSW: "┤",
+        CenterNEW: "┴",
+        CenterESW: "┬",
+        CenterNE:  "└",
+        CenterWN:  "┘",
+        CenterSW:  "┐",
+        CenterES:  "┌",
+        Row:       "─",
+        Column:    "│",
+        Dim:       true,
+    }
+
+    // ASCIIBorderStyle draws the borders with +, - and |, without any
+    // escape sequence
+    ASCIIBorderStyle = BorderStyle{
+        CenterAll: "+",
+        CenterNES: "+",
+        CenterNSW: "+",
+        CenterNEW: "+",
+        CenterESW: "+",
+        CenterNE:  "+",
+        CenterWN:  "+",
+        CenterSW:  "+",
+        CenterES:  "+",
+        Row:       "-",
+        Column:    "|",
+    }
+)
+
+// Section is a part of the table with its own alignment
+type Section int
+
+const (
+    SectionHeader Section = iota
+    SectionBody
+    SectionFooter
+)
+
+// BorderMode selects the lines of the table in a single setting
+type BorderMode int
+
+const (
+    BordersAll BorderMode = iota
+    BordersNone
+    BordersOuter
+    BordersHorizontal
+    BordersVertical
+)
+
+// Overflow decides what happens to a cell wider than the maximum width
+type Overflow int
+
+const (
+    OverflowWrap Overflow = iota
+    OverflowTruncate
+)
+
+// WrapMode decides how the cells wider than the maximum width are wrapped
+type WrapMode int
+
+const (
+    // Break lines between words, letting the words wider than the column
+    // overflow it. This is the default.
+    WrapWord WrapMode = iota
+    // Break lines between words, and the words wider than the column at
+    // exactly its width
+    WrapHard
+    // Never wrap, the column grows to fit the widest line
+    WrapNone
+)
+
 type Table struct {
     out                     io.Writer
     rows                    [][]string
@@ -64,26 +177,117 @@ type Table struct {
     cs                      map[int]int
     rs                      map[int]int
     headers                 [][]string
+    headerKeys              []string
+    footers                 []string
     autoFmt                 bool
+    headerAttr              Colors
+    headerTransform         func(col int, raw string) string
+    headerVertical          bool
+    headerSpans             []int
+    headerGroups            []HeaderCell
+    hdrStyle                bool
+    caseLang                language.Tag
+    groupHdrStyle           bool
+    autoAlignNum            bool
+    numericPatterns         []*regexp.Regexp
     autoWrap                bool
+    wrapMode                WrapMode
     reflowText              bool
+    wrapCache               bool
+    wrapCells               map[wrapKey]wrappedCell
+    paragraphGap            int
+    tabWidth                int
+    escapeBorders           bool
+    glyphEscaper            *strings.Replacer
+    normalizeWidth          bool
+    stripJoiners            bool
+    csvSeparator            rune
+    csvUnmasked             bool
+    maxCellHeight           int
     mW                      int
+    hdrMW                   int
     tColumn                 int
     tRow                    int
     hAlign                  int
     fAlign                  int
     align                   int
+    centerBias              Bias
     newLine                 string
+    clearEOL                bool
+    noTrailingNewline       bool
+    noTrailingSpace         bool
     rowLine                 bool
+    colLine                 bool
+    groupCol                int
     autoMergeCells          bool
     columnsToAutoMergeCells map[int]bool
     noWhiteSpace            bool
     tablePadding            string
     hdrLine                 bool
+    hdrAtBottom             bool
+    hdrFollowAlign          bool
     colSize                 int
     headerParams            []string
     columnsParams           []string
     columnsAlign            []int
+    columnsAlignMap         map[int]int
+    sectionAlign            map[Section]map[int]int
+    rowHeights              map[int]int
+    spacers                 map[int]bool
+    collapseBlankRows       bool
+    removeBlankRows         bool
+    columnNames             []string
+    padShortRows            bool
+    columnIndex             map[string]int
+    ignoreUnknown           bool
+    cellColors              map[int][]Colors
+    columnColors            map[int]Colors
+    stripeEven              Colors
+    stripeOdd               Colors
+    richPadding             bool
+    columnFormatters        map[int][]func(string) string
+    valueTransformer        func(row, col int, value string) string
+    numberFormat            func(string) string
+    columnOverflow          map[int]Overflow
+    columnBools             map[int][2]string
+    columnHumanize          map[int]Humanize
+    columnMasks             map[int]mask
+    hideEmptyColumns        bool
+    autoIndex               bool
+    indexHeader             string
+    rtl                     bool
+    mirrored                bool
+    autoWidth               bool
+    autoWidthMargin         int
+    maxTableWidth           int
+    shrink                  ShrinkStrategy
+    colMinWidths            map[int]int
+    floorWidths             map[int]int
+    colMaxWidths            map[int]int
+    trueValues              []string
+    falseValues             []string
+    colPercentile           map[int]float64
+    bom                     bool
+    borders                 Border
+    style                   BorderStyle
+    rowRenderedHook         func(rowIdx int)
+    outputs                 []output
+    outputFilter            func(full string) string
+    codeFence               string
+    codeFenceLang           string
+    legend                  []LegendEntry
+    caption                 bool
+    captionText             string
+    captionAlign            int
+    summaries               []Summary
+    summary                 [][]string
+    kvFlatten               bool
+    pageRows                []int
+    morePages               bool
+    continued               string
+    streamWidths            []int
+    stream                  *Table
+    streamRows              int
 }
 
 // Start New Table
@@ -97,36 +301,217 @@ func NewWriter(writer io.Writer) *Table {
         rs:            make(map[int]int),
         headers:       [][]string{},
         autoFmt:       true,
+        hdrStyle:      true,
+        groupHdrStyle: true,
+        headerAttr:    Colors{Bold},
+        autoAlignNum:  true,
         autoWrap:      true,
         reflowText:    true,
+        paragraphGap:  1,
         mW:            MAX_ROW_WIDTH,
         tColumn:       -1,
         tRow:          -1,
         hAlign:        ALIGN_DEFAULT,
         fAlign:        ALIGN_DEFAULT,
+        captionAlign:  ALIGN_CENTER,
         align:         ALIGN_DEFAULT,
         newLine:       NEWLINE,
         rowLine:       false,
+        groupCol:      -1,
         hdrLine:       true,
+        colLine:       true,
         colSize:       -1,
+        indexHeader:   "#",
         headerParams:  []string{},
         columnsParams: []string{},
-        columnsAlign:  []int{}}
+        columnsAlign:  []int{},
+        borders:       Border{Left: true, Right: true, Top: true, Bottom: true},
+        style:         DefaultBorderStyle}
     return t
 }
 
+// Start New Table
+// Write to os.Stdout, the same as NewWriter(os.Stdout)
+func NewStdoutWriter() *Table {
+    return NewWriter(os.Stdout)
+}
+
+// Start New Table
+// Write to os.Stderr, the same as NewWriter(os.Stderr)
+func NewStderrWriter() *Table {
+    return NewWriter(os.Stderr)
+}
+
 // Render table output
+// The table is written as text to the writer given to NewWriter, and to
+// every output added with AddOutput in its own format. Write errors are
+// ignored, see RenderErr.
 func (t *Table) Render() {
-    t.printLine(true, true, false)
+    t.RenderErr()
+}
+
+// Render table output, returning the first write error
+// This renders like Render, and stops writing at the first error, which is
+// returned, so that a table cut short by a closed connection or a full disk
+// does not go unnoticed. Errors writing the outputs added with AddOutput
+// are not returned.
+func (t *Table) RenderErr() error {
+    ew := &errWriter{w: io.MultiWriter(append([]io.Writer{t.out}, t.outputWriters(FormatText)...)...)}
+    t.renderTo(ew)
+    t.renderOutputs()
+    return ew.err
+}
+
+// Render table output to w
+// The table is written as text to w only, instead of the writer given to
+// NewWriter and the outputs added with AddOutput. The first error writing
+// to w is returned, and nothing more is written after it.
+func (t *Table) RenderTo(w io.Writer) error {
+    ew := &errWriter{w: w}
+    t.renderTo(ew)
+    return ew.err
+}
+
+// Render table output as a string
+// The table is rendered as text, as RenderTo does, into a string instead of
+// a writer. Rendering only reads the rows and settings of the table, so it
+// can be called any number of times and returns the same string.
+func (t *Table) RenderString() string {
+    var buf bytes.Buffer
+    t.RenderTo(&buf)
+    return buf.String()
+}
+
+// Render the table as text to w, through the output filter if any
+func (t *Table) renderTo(w io.Writer) {
+    t.rendered(w).writeText(w)
+}
+
+// Write the text of the table to w, trimmed, filtered and fenced as set.
+// The table is the copy laid out by rendered, whose writer is replaced.
+func (t *Table) writeText(w io.Writer) {
+    if t.outputFilter != nil || t.noTrailingNewline || t.noTrailingSpace || t.codeFence != "" {
+        var buf bytes.Buffer
+        t.out = &buf
+        t.renderText()
+        text := buf.String()
+        if t.noTrailingSpace {
+            text = trimLines(text)
+        }
+        if t.outputFilter != nil {
+            text = t.outputFilter(text)
+        }
+        if t.codeFence != "" {
+            text = t.codeFence + t.codeFenceLang + t.newLine + text + t.codeFence + t.newLine
+        }
+        if t.noTrailingNewline {
+            text = strings.TrimSuffix(text, t.newLine)
+        }
+        io.WriteString(w, text)
+        return
+    }
+    t.out = w
+    t.renderText()
+}
+
+// Return the copy of the table printed when rendering to w, laid out
+// without its hidden columns, with the index column, fitted to the width
+// and mirrored right to left, as set. The table is left unchanged.
+func (t *Table) rendered(w io.Writer) *Table {
+    if hidden := t.emptyColumns(); len(hidden) > 0 {
+        return t.withoutColumns(hidden).rendered(w)
+    }
+    if t.autoIndex {
+        return t.withIndex().rendered(w)
+    }
+    if width, ok := t.autoWidthLimit(w); ok {
+        return t.fitWidth(width).rendered(w)
+    }
+    if t.rtl && !t.mirrored {
+        return t.mirroredColumns().rendered(w)
+    }
+    return t.laidOut()
+}
+
+// Render the table with the widths and alignment of a format spec
+// The spec holds one verb per column, such as "%-10s %8s %v", separated
+// by spaces. Only the s and v verbs are supported, with an optional width,
+// the minimum width of the column, and an optional - flag to align the
+// column left. Columns without the flag are aligned right, like fmt. No
+// fitting narrows a column below its width in the spec. The table is
+// written to the writer of the table only, not to the outputs added with
+// AddOutput, and the first error writing it is returned.
+func (t *Table) RenderFormat(spec string) error {
+    matches := verb.FindAllStringSubmatchIndex(spec, -1)
+    prev := 0
+    for _, m := range matches {
+        if strings.TrimSpace(spec[prev:m[0]]) != "" {
+            return fmt.Errorf("invalid format spec %q", spec)
+        }
+        prev = m[1]
+    }
+    if strings.TrimSpace(spec[prev:]) != "" {
+        return fmt.Errorf("invalid format spec %q", spec)
+    }
+    if len(matches) != len(t.cs) {
+        return fmt.Errorf("format spec has %d columns, table has %d", len(matches), len(t.cs))
+    }
+
+    // The spec applies to this rendering only, the table is left unchanged
+    l := *t
+    l.cs = make(map[int]int, len(t.cs))
+    for y, w := range t.cs {
+        l.cs[y] = w
+    }
+    l.floorWidths = make(map[int]int, len(matches))
+    align := make([]int, len(matches))
+    for i, m := range matches {
+        align[i] = ALIGN_RIGHT
+        if m[3] > m[2] {
+            align[i] = ALIGN_LEFT
+        }
+        if m[5] > m[4] {
+            width, err := strconv.Atoi(spec[m[4]:m[5]])
+            if err != nil {
+                return fmt.Errorf("invalid width in format spec %q", spec)
+            }
+            if l.cs[i] < width {
+                l.cs[i] = width
+            }
+            l.floorWidths[i] = width
+        }
+    }
+    l.columnsAlign = align
+    ew := &errWriter{w: t.out}
+    l.renderTo(ew)
+    return ew.err
+}
+
+// Render the table, once laid out, as text to t.out
+func (t *Table) renderText() {
+    if t.bom {
+        fmt.Fprint(t.out, BOM)
+    }
+    if t.borders.Top {
+        t.printTopLine()
+    }
     t.printHeading()
     if t.autoMergeCells {
         t.printRowsMergeCells()
     } else {
         t.printRows()
     }
-    if !t.rowLine {
-        t.printLine(true, false, true)
+    if t.hasSummary() {
+        t.printSummary()
     }
+    if t.hasBottomHeading() {
+        t.printBottomHeading()
+    }
+    if (!t.rowLine || t.hasBottomHeading()) && t.borders.Bottom {
+        t.printSpanLine(true, false, true, t.hasBottomHeading(), false)
+    }
+    t.printCaption()
+    t.printLegend()
 }
 
 const (
@@ -137,20 +522,275 @@ const (
 // Set table header
 func (t *Table) SetHeader(keys []string) {
     t.colSize = len(keys)
+    t.headerKeys = append(t.headerKeys, keys...)
     for i, v := range keys {
+        if len(t.headerSpans) > 0 {
+            t.headers = append(t.headers, t.parseSpannedHeader(v, i))
+            continue
+        }
         lines := t.parseDimension(v, i, headerRowIdx)
         t.headers = append(t.headers, lines)
     }
 }
 
+// Set table footer
+// The footer is printed below the rows, separated from them by a line.
+// Footer cells left empty get the summary of their column, if any, set by
+// SetSummaryRow.
+func (t *Table) SetFooter(keys []string) {
+    t.footers = keys
+}
+
+// Set Header Spans
+// Header cell i covers the next spans[i] columns, with its label centered
+// over their combined width, and the line beneath separating only the
+// header cells. Spans are the way to label a group of columns. Call it
+// before SetHeader: the columns are then sized to fit their header cell
+// at render, rather than their header, and header colors are given per
+// header cell.
+func (t *Table) SetHeaderSpans(spans []int) {
+    t.headerSpans = spans
+}
+
+// Parse header cell i, covering columns from the first column of its span,
+// without sizing the column to it
+func (t *Table) parseSpannedHeader(v string, i int) []string {
+    y, _ := t.headerSpan(i)
+    width, ok := t.cs[y]
+    lines := t.parseDimension(v, y, headerRowIdx)
+    if ok {
+        t.cs[y] = width
+    } else {
+        delete(t.cs, y)
+    }
+    return lines
+}
+
+// Get the first column and the number of columns of header cell i
+func (t *Table) headerSpan(i int) (int, int) {
+    if len(t.headerSpans) == 0 {
+        return i, 1
+    }
+    y := 0
+    for k := 0; k < i && k < len(t.headerSpans); k++ {
+        y += t.headerSpans[k]
+    }
+    if i >= len(t.headerSpans) {
+        return y + i - len(t.headerSpans), 1
+    }
+    if t.headerSpans[i] < 1 {
+        return y, 1
+    }
+    return y, t.headerSpans[i]
+}
+
+// Check whether the boundary after column y lies inside a header cell
+func (t *Table) insideHeaderSpan(y int) bool {
+    for i := range t.headers {
+        start, span := t.headerSpan(i)
+        if start <= y && y < start+span-1 {
+            return true
+        }
+    }
+    return false
+}
+
+// Get the width of n columns from column y, with the separators between
+func (t *Table) spanWidth(y, n int) int {
+    width := 0
+    for k := y; k < y+n; k++ {
+        width += t.cs[k]
+    }
+    if t.noWhiteSpace {
+        return width + (n-1)*DisplayWidth(t.tablePadding)
+    }
+    return width + (n-1)*(2+DisplayWidth(t.style.column()))
+}
+
+// Set Header Style
+// This would enable / disable the title case and header attribute of the
+// header cells covering a single column. Default is on (true).
+func (t *Table) SetHeaderStyle(style bool) {
+    t.hdrStyle = style
+}
+
+// Set Group Header Style
+// This would enable / disable the title case and header attribute of the
+// header cells spanning several columns, the group labels set with
+// SetHeaderSpans. Default is on (true).
+func (t *Table) SetGroupHeaderStyle(style bool) {
+    t.groupHdrStyle = style
+}
+
+// Turn header autoformatting on/off. Default is on (true).
+// Turning it off turns off SetHeaderStyle and SetGroupHeaderStyle too.
+func (t *Table) SetAutoFormatHeaders(auto bool) {
+    t.autoFmt = auto
+}
+
+// Turn automatic right alignment of numbers on/off. Default is on (true).
+// When off, cells without an explicit alignment are left aligned.
+func (t *Table) SetAutoAlignNumbers(auto bool) {
+    t.autoAlignNum = auto
+}
+
+// Turn automatic right alignment of numbers on/off, the same as
+// SetAutoAlignNumbers
+func (t *Table) SetAutoNumericAlignment(auto bool) {
+    t.SetAutoAlignNumbers(auto)
+}
+
+// Set Numeric Patterns
+// This sets the patterns a cell must match, trimmed of white space, to be a
+// number, instead of the default decimals, such as "-1,234.5", and
+// percentages, such as "12.5%". Numbers are aligned right and formatted by
+// SetNumberFormat, and those that read as decimals once their thousands
+// separators are removed can be humanized and summed. No patterns restore
+// the defaults.
+func (t *Table) SetNumericPatterns(patterns ...*regexp.Regexp) {
+    t.numericPatterns = patterns
+}
+
+// Set Header Follow Column Alignment
+// This would enable / disable aligning each header like the data of its
+// column instead of using the header alignment. Columns with the default
+// alignment are right aligned when all of their cells are numbers, and
+// left aligned otherwise.
+func (t *Table) SetHeaderFollowColumnAlignment(follow bool) {
+    t.hdrFollowAlign = follow
+}
+
+// Set Header Transform
+// The function replaces Title for formatting each line of header text and
+// is applied even when header autoformatting is off. Passing nil restores
+// the default formatting.
+func (t *Table) SetHeaderTransform(transform func(col int, raw string) string) {
+    t.headerTransform = transform
+}
+
+// Turn bold autoformatted headers on/off. Default is on (true).
+func (t *Table) SetHeaderBold(bold bool) {
+    if bold {
+        t.SetHeaderAttribute(Colors{Bold})
+    } else {
+        t.SetHeaderAttribute(nil)
+    }
+}
+
+// Set Header Attribute
+// This sets the emphasis of autoformatted headers, such as
+// Colors{UnderlineSingle} or a color, in place of bold. Empty colors
+// disable the emphasis.
+func (t *Table) SetHeaderAttribute(attr Colors) {
+    t.headerAttr = attr
+}
+
+// Set Header Vertical
+// This would enable / disable stacking the header text one character per
+// line, so that narrow columns are not widened by long headers.
+// It must be called before SetHeader.
+func (t *Table) SetHeaderVertical(vertical bool) {
+    t.headerVertical = vertical
+}
+
+// Turn automatic multiline text adjustment on/off. Default is on (true).
+func (t *Table) SetAutoWrapText(auto bool) {
+    t.autoWrap = auto
+}
+
+// Set Wrap Mode
+// This sets how cells wider than the maximum width are wrapped: WrapWord,
+// the default, WrapHard or WrapNone. Column overflows set with
+// SetColumnOverflow take precedence, and nothing is wrapped when automatic
+// wrapping is off.
+func (t *Table) SetWrapMode(mode WrapMode) {
+    t.wrapMode = mode
+}
+
+// Set the Reflow During Auto Wrap
+// This would enable / disable joining the paragraphs of a cell before wrapping
+func (t *Table) SetReflowDuringAutoWrap(auto bool) {
+    t.reflowText = auto
+}
+
+// Set Paragraph Gap
+// This sets the number of blank lines inserted between the paragraphs of a
+// cell when reflowing during auto wrap is disabled. A blank line of the
+// text is kept as a single blank line, without any gap around it. Negative
+// values are treated as zero.
+func (t *Table) SetParagraphGap(n int) {
+    if n < 0 {
+        n = 0
+    }
+    t.paragraphGap = n
+}
+
+// Set Escape Border Chars
+// This would enable / disable replacing the glyphs used to draw the borders
+// in the content of the cells with ASCII lookalikes, so that a "│" in the
+// data doesn't look like a column separator. Cells are escaped at render,
+// whether they were added before or after enabling it, for the glyphs of
+// the border style set then: with ASCIIBorderStyle, a "|" is replaced with
+// "¦", while "-" and "+" are left as they are.
+func (t *Table) SetEscapeBorderChars(escape bool) {
+    t.escapeBorders = escape
+}
+
+// Set Tab Width
+// This expands the tabs within cells to spaces up to the next multiple of
+// width, so that tab separated text lines up inside the cell. Columns of
+// data should be appended as separate cells; this is only a fallback for
+// text that already contains tabs. Zero or a negative value leaves tabs as
+// they are.
+func (t *Table) SetTabWidth(width int) {
+    t.tabWidth = width
+}
+
+// Set Max Cell Height
+// This caps the number of lines a cell may occupy, and therefore the height
+// of every row. The limit is applied after wrapping, so it counts wrapped
+// lines rather than the lines of the original text. When content is cut, an
+// ellipsis is added to the last kept line, truncating that line if needed to
+// stay within the column width. Zero or a negative value disables the limit.
+func (t *Table) SetMaxCellHeight(n int) {
+    t.maxCellHeight = n
+}
+
 // Set the Default column width
+// Lines only break between words, so a column is widened to fit its
+// longest word, and a wide rune is never split, even when width is
+// smaller than the rune.
 func (t *Table) SetColWidth(width int) {
     t.mW = width
 }
 
+// Set the maximum width of the header text before it wraps
+// Headers wrap on word boundaries like cells, at the default column width
+// unless a header width is set. It must be called before SetHeader.
+func (t *Table) SetHeaderColWidth(width int) {
+    t.hdrMW = width
+}
+
+// Set the maximum width for a column
+// Cells of the column wrap at width instead of the width set by
+// SetColWidth. Together with SetColMinWidth, the column is clamped between
+// both, the minimum winning when it is larger. It must be called before the
+// rows are appended.
+func (t *Table) SetColMaxWidth(column int, width int) {
+    if t.colMaxWidths == nil {
+        t.colMaxWidths = make(map[int]int)
+    }
+    t.colMaxWidths[column] = width
+}
+
 // Set the minimal width for a column
+// Fitting the table to a width never narrows the column below it.
 func (t *Table) SetColMinWidth(column int, width int) {
     t.cs[column] = width
+    if t.colMinWidths == nil {
+        t.colMinWidths = make(map[int]int)
+    }
+    t.colMinWidths[column] = width
 }
 
 // Set Header Alignment
@@ -158,6 +798,20 @@ func (t *Table) SetHeaderAlignment(hAlign int) {
     t.hAlign = hAlign
 }
 
+// Set Footer Alignment
+// This sets the alignment of the footer cells, and of the summary row.
+// Default is right, like numbers, unless the column has an alignment.
+func (t *Table) SetFooterAlignment(fAlign int) {
+    t.fAlign = fAlign
+}
+
+// Set Center Bias
+// This would set the side given the odd space when centering a cell or a
+// header: BiasRight, the default, or BiasLeft
+func (t *Table) SetCenterBias(bias Bias) {
+    t.centerBias = bias
+}
+
 // Set Table Alignment
 func (t *Table) SetAlignment(align int) {
     t.align = align
@@ -189,23 +843,363 @@ func (t *Table) SetColumnAlignment(keys []int) {
     }
 }
 
+// Set Column Alignment Map
+// This sets the alignment of the columns given by index only, leaving the
+// others at the table alignment, or at the alignment given to
+// SetColumnAlignment, which the map overrides.
+func (t *Table) SetColumnAlignmentMap(aligns map[int]int) {
+    t.columnsAlignMap = make(map[int]int, len(aligns))
+    for y, v := range aligns {
+        switch v {
+        case ALIGN_CENTER, ALIGN_LEFT, ALIGN_RIGHT:
+        default:
+            v = ALIGN_DEFAULT
+        }
+        t.columnsAlignMap[y] = v
+    }
+}
+
+// Set Column Alignment For
+// This sets the alignment of a column in a section of the table only:
+// the header, the body or the footer, the summary row. It takes precedence
+// over the alignment of the section, SetHeaderAlignment for the header and
+// the column alignments for the body. The footer falls back to the body.
+func (t *Table) SetColumnAlignmentFor(section Section, col int, align int) {
+    switch align {
+    case ALIGN_CENTER, ALIGN_LEFT, ALIGN_RIGHT:
+    default:
+        align = ALIGN_DEFAULT
+    }
+    if t.sectionAlign == nil {
+        t.sectionAlign = make(map[Section]map[int]int)
+    }
+    if t.sectionAlign[section] == nil {
+        t.sectionAlign[section] = make(map[int]int)
+    }
+    t.sectionAlign[section][col] = align
+}
+
+// Set Pad Short Rows
+// This would enable / disable rendering rows with fewer cells than the
+// table has columns with empty cells, so that the table is rectangular.
+// Rows are padded to the widest row, even one appended later.
+func (t *Table) SetPadShortRows(pad bool) {
+    t.padShortRows = pad
+}
+
+// Set Column Formatter
+// The formatter is applied to every cell of the column as it is appended,
+// before its width is measured and before it is wrapped. Calling it again
+// for the same column adds another formatter, run after the previous ones.
+func (t *Table) SetColumnFormatter(col int, formatter func(string) string) {
+    if t.columnFormatters == nil {
+        t.columnFormatters = make(map[int][]func(string) string)
+    }
+    t.columnFormatters[col] = append(t.columnFormatters[col], formatter)
+}
+
+// Set Value Transformer
+// The transformer is applied to every data cell as it is appended, with the
+// index of its row and column, before its width is measured and before it
+// is wrapped. It runs before the column formatters, so they get the value
+// it returns. Header and summary cells are left unchanged.
+func (t *Table) SetValueTransformer(transformer func(row, col int, value string) string) {
+    t.valueTransformer = transformer
+}
+
+// Set Number Format
+// The format is applied to every data cell holding a number, such as
+// "1234.5", "-1,234" or "12%", or a match of SetNumericPatterns, as it is
+// appended, before its width is measured, so that it can add thousands
+// separators or fix the decimal places. It runs after the column formatters
+// and humanizing, and gets the value trimmed of white space. Header and
+// summary cells are left unchanged.
+func (t *Table) SetNumberFormat(format func(string) string) {
+    t.numberFormat = format
+}
+
+// Set Column Width Percentile
+// The width of the column is set at render to the p-th percentile, from
+// 0 to 100, of the widths of its cells, so that a few very long values do
+// not widen it. Wider cells are wrapped again to that width, or truncated
+// with OverflowTruncate. The percentile overrides SetColMinWidth and the
+// widths reached by wrapping, but the column still fits its header and its
+// longest word.
+func (t *Table) SetColWidthPercentile(col int, p float64) {
+    if t.colPercentile == nil {
+        t.colPercentile = make(map[int]float64)
+    }
+    t.colPercentile[col] = p
+}
+
+// Set Column Bool
+// Data cells of the column holding a boolean value are replaced with
+// trueGlyph or falseGlyph, such as "✓" and "✗", and centered unless the
+// column has an alignment. Other cells are left unchanged.
+func (t *Table) SetColumnBool(col int, trueGlyph, falseGlyph string) {
+    if t.columnBools == nil {
+        t.columnBools = make(map[int][2]string)
+    }
+    t.columnBools[col] = [2]string{trueGlyph, falseGlyph}
+}
+
+// Set Bool Values
+// This sets the values, compared ignoring case, read as true and false by
+// SetColumnBool. Default is "true" and "false".
+func (t *Table) SetBoolValues(trueValues, falseValues []string) {
+    t.trueValues = trueValues
+    t.falseValues = falseValues
+}
+
+// Set Column Mask
+// Data cells of the column are printed with every character but the last
+// keepLast replaced with maskChar, such as "••••1234", to keep secrets out
+// of logs. Values no longer than keepLast are masked entirely. The width of
+// the column is measured on the masked values, and the original values are
+// kept.
+func (t *Table) SetColumnMask(col int, maskChar rune, keepLast int) {
+    if t.columnMasks == nil {
+        t.columnMasks = make(map[int]mask)
+    }
+    t.columnMasks[col] = mask{char: maskChar, keepLast: keepLast}
+}
+
+// Set Normalize Width
+// This would enable / disable removing the zero width spaces of cells and
+// headers before they are measured, so that stray invisible characters
+// neither widen a column nor keep identical cells from merging. Zero width
+// joiners and non-joiners are only removed when they join nothing, unless
+// SetStripJoiners is on. It must be called before the rows are appended.
+func (t *Table) SetNormalizeWidth(normalize bool) {
+    t.normalizeWidth = normalize
+}
+
+// Set Strip Joiners
+// This would enable / disable removing every zero width joiner and
+// non-joiner when normalizing the width, including those of emoji
+// sequences. Default is off (false).
+func (t *Table) SetStripJoiners(strip bool) {
+    t.stripJoiners = strip
+}
+
+// Set Column Overflow
+// Cells of the column wider than the maximum width are wrapped with
+// OverflowWrap, or cut to a single line with an ellipsis with
+// OverflowTruncate. Data cells are cut on the side opposite to their
+// alignment, known when they are appended: at the end when aligned left,
+// at the start when aligned right, like numbers, and at both ends when
+// centered. This overrides SetAutoWrapText for the column.
+func (t *Table) SetColumnOverflow(col int, overflow Overflow) {
+    if t.columnOverflow == nil {
+        t.columnOverflow = make(map[int]Overflow)
+    }
+    t.columnOverflow[col] = overflow
+}
+
+// Set Rich Padding
+// This would enable / disable extending the colors given to Rich over the
+// padding of the cells, so that a background color reads as a solid band
+func (t *Table) SetRichPadding(fill bool) {
+    t.richPadding = fill
+}
+
 // Set New Line
 func (t *Table) SetNewLine(nl string) {
     t.newLine = nl
 }
 
+// Set Trailing Newline
+// This would enable / disable the new line ending the last line of the
+// table. Default is on (true).
+func (t *Table) SetTrailingNewline(newline bool) {
+    t.noTrailingNewline = !newline
+}
+
+// Set Trailing Space
+// This would enable / disable the white space ending the lines, such as the
+// padding of the last cells when there is no right border. Default is on
+// (true).
+func (t *Table) SetTrailingSpace(space bool) {
+    t.noTrailingSpace = !space
+}
+
+// Set Clear EOL
+// This would enable / disable ending every line with the ANSI sequence
+// erasing the rest of the line, before the new line, so that a table redrawn
+// in place leaves nothing of a previous, wider one.
+func (t *Table) SetClearEOL(clear bool) {
+    t.clearEOL = clear
+}
+
+// Get the end of a rendered line
+func (t *Table) lineEnd() string {
+    if t.clearEOL {
+        return CLEAR_EOL + t.newLine
+    }
+    return t.newLine
+}
+
+// Set BOM
+// This would enable / disable writing a UTF-8 byte order mark before the
+// table, which helps programs such as Excel detect the encoding
+func (t *Table) SetBOM(bom bool) {
+    t.bom = bom
+}
+
+// Set Caption
+// This would enable / disable printing text below the table, wrapped to
+// the width of the table. The caption does not change the width of any
+// column.
+func (t *Table) SetCaption(caption bool, text string) {
+    t.caption = caption
+    t.captionText = text
+}
+
+// Set Caption Alignment
+// Default is center
+func (t *Table) SetCaptionAlignment(align int) {
+    t.captionAlign = align
+}
+
+// Print the caption, if any, wrapped to the width of the table
+func (t *Table) printCaption() {
+    if !t.caption || t.captionText == "" || t.morePages {
+        return
+    }
+    width := t.getTableWidth()
+    lines, _ := WrapString(t.captionText, width)
+    padFunc := t.pad(t.captionAlign)
+    for _, line := range lines {
+        fmt.Fprint(t.out, strings.TrimRight(padFunc(line, SPACE, width), SPACE), t.lineEnd())
+    }
+}
+
+// Set Table Border
+// This would enable / disable line around the table
+func (t *Table) SetBorder(border bool) {
+    t.SetBorders(Border{border, border, border, border})
+}
+
+// Set Table Borders
+// This would enable / disable each side of the line around the table
+func (t *Table) SetBorders(border Border) {
+    t.borders = border
+}
+
+// Set Border Style
+// This sets the glyphs the lines and the column separators are drawn with,
+// such as ASCIIBorderStyle. Default is DefaultBorderStyle.
+func (t *Table) SetBorderStyle(style BorderStyle) {
+    t.style = style
+}
+
+// Set Border Mode
+// This would set the borders, header line, row line and column line for
+// the mode, overriding earlier calls to SetBorders and the line setters:
+//
+//   BordersAll         every border and line
+//   BordersNone        no border nor line
+//   BordersOuter       the four borders only
+//   BordersHorizontal  the top and bottom borders, header and row lines
+//   BordersVertical    the left and right borders and column lines
+func (t *Table) SetBorderMode(mode BorderMode) {
+    all := mode == BordersAll
+    horizontal := all || mode == BordersHorizontal
+    vertical := all || mode == BordersVertical
+    outer := all || mode == BordersOuter
+
+    t.SetBorders(Border{
+        Left:   outer || vertical,
+        Right:  outer || vertical,
+        Top:    outer || horizontal,
+        Bottom: outer || horizontal,
+    })
+    t.SetHeaderLine(horizontal)
+    t.SetRowLine(horizontal)
+    t.SetColumnLine(vertical)
+}
+
+// Set Row Rendered Hook
+// The hook is called with the index of each data row once the row, and
+// its row line if any, has been written. It is not called for the header
+// or for separator lines.
+func (t *Table) SetRowRenderedHook(hook func(rowIdx int)) {
+    t.rowRenderedHook = hook
+}
+
+// Set Output Filter
+// The filter is called once with the complete text of the table, and its
+// result is written instead. With a filter the table is rendered into a
+// buffer first, so nothing is written until the table is complete, and the
+// row rendered hook runs before the output is written.
+func (t *Table) SetOutputFilter(filter func(full string) string) {
+    t.outputFilter = filter
+}
+
+// Set Code Fence
+// The table is written between two lines holding the fence, such as "```",
+// for pasting it into Markdown. The language hint lang, if any, follows the
+// opening fence. The fence is added after the output filter, and does not
+// change the widths. An empty fence disables it.
+func (t *Table) SetCodeFence(fence, lang string) {
+    t.codeFence = fence
+    t.codeFenceLang = lang
+}
+
+// Set Case Language
+// The auto formatted header is upper cased with the rules of the language
+// tag, such as language.Turkish. Default is language.Und, which upper cases
+// the same in every language.
+func (t *Table) SetCaseLanguage(tag language.Tag) {
+    t.caseLang = tag
+}
+
 // Set Header Line
 // This would enable / disable a line after the header
 func (t *Table) SetHeaderLine(line bool) {
     t.hdrLine = line
 }
 
+// Set Header At Bottom
+// This would enable / disable repeating the header below the rows
+func (t *Table) SetHeaderAtBottom(bottom bool) {
+    t.hdrAtBottom = bottom
+}
+
+// Set Column Line
+// This would enable / disable a line between the columns of the table
+func (t *Table) SetColumnLine(line bool) {
+    t.colLine = line
+}
+
+// Set Collapse Blank Rows
+// This would enable / disable printing a single row for consecutive blank
+// rows, rows whose cells hold only white space.
+func (t *Table) SetCollapseBlankRows(collapse bool) {
+    t.collapseBlankRows = collapse
+}
+
+// Set Remove Blank Rows
+// This would enable / disable skipping every blank row, rows whose cells
+// hold only white space.
+func (t *Table) SetRemoveBlankRows(remove bool) {
+    t.removeBlankRows = remove
+}
+
 // Set Row Line
 // This would enable / disable a line on each row of the table
 func (t *Table) SetRowLine(line bool) {
     t.rowLine = line
 }
 
+// Set Group Separator Column
+// This would draw a line between consecutive rows whose values in column
+// col differ. It has no effect when a line is drawn on each row already.
+// A negative column disables it.
+func (t *Table) SetGroupSeparatorColumn(col int) {
+    t.groupCol = col
+}
+
 // Set Auto Merge Cells
 // This would enable / disable the merge of cells with identical values
 func (t *Table) SetAutoMergeCells(auto bool) {
@@ -231,7 +1225,14 @@ func (t *Table) SetAutoMergeCellsByColumnIndex(cols []int) {
 // If something that is not a slice is passed, error will be returned.
 // The tag specified by "tablewriter" for the struct becomes the header.
 // If not specified or empty, the field name will be used.
+// A field tagged "-" is skipped, and gets no column.
+// The fields of an embedded struct get their own columns, unless it is
+// tagged with a header or implements fmt.Stringer.
 // The field of the first element of the slice is used as the header.
+// Columns follow the declaration order of the fields, unless placed by the
+// tag option order=N at position N, counted from 0, or pinned with
+// SetColumnOrder. The other columns fill the remaining positions in
+// declaration order.
 // If the element implements fmt.Stringer, the result will be used.
 // And the slice contains nil, it will be skipped without rendering.
 func (t *Table) SetStructs(v interface{}) error {
@@ -263,17 +1264,45 @@ func (t *Table) SetStructs(v interface{}) error {
         default:
             return fmt.Errorf("invalid kind %s", e.Kind())
         }
-        n := e.NumField()
-        headers := make([]string, n)
-        for i := 0; i < n; i++ {
-            f := e.Field(i)
-            header := f.Tag.Get("tablewriter")
-            if header == "" {
-                header = f.Name
+        columns, err := structColumns(e, nil)
+        if err != nil {
+            return err
+        }
+        columns, err = orderColumns(columns)
+        if err != nil {
+            return err
+        }
+        if len(columns) == 0 {
+            return errors.New("no field to render")
+        }
+        headers := make([]string, len(columns))
+        aligns := make(map[int]int)
+        for i, c := range columns {
+            headers[i] = c.header
+            if c.aligned {
+                aligns[i] = c.align
+            }
+        }
+        order, err := t.columnOrder(headers)
+        if err != nil {
+            return err
+        }
+        t.SetHeader(reorder(headers, order))
+
+        // Align the columns with an align option, keeping the others
+        if len(aligns) > 0 {
+            columnsAlign := make([]int, len(headers))
+            for i, j := range order {
+                columnsAlign[i] = t.align
+                if i < len(t.columnsAlign) {
+                    columnsAlign[i] = t.columnsAlign[i]
+                }
+                if align, ok := aligns[j]; ok {
+                    columnsAlign[i] = align
+                }
             }
-            headers[i] = header
+            t.columnsAlign = columnsAlign
         }
-        t.SetHeader(headers)
 
         for i := 0; i < vv.Len(); i++ {
             item := reflect.Indirect(vv.Index(i))
@@ -288,27 +1317,14 @@ func (t *Table) SetStructs(v interface{}) error {
                 // skip rendering
                 continue
             }
-            nf := item.NumField()
-            if n != nf {
+            if item.NumField() != e.NumField() {
                 return errors.New("invalid num of field")
             }
-            rows := make([]string, nf)
-            for j := 0; j < nf; j++ {
-                f := reflect.Indirect(item.Field(j))
-                if f.Kind() == reflect.Ptr {
-                    f = f.Elem()
-                }
-                if f.IsValid() {
-                    if s, ok := f.Interface().(fmt.Stringer); ok {
-                        rows[j] = s.String()
-                        continue
-                    }
-                    rows[j] = fmt.Sprint(f)
-                } else {
-                    rows[j] = "nil"
-                }
+            rows := make([]string, len(columns))
+            for j, c := range columns {
+                rows[j] = fieldText(fieldByIndex(item, c.index))
             }
-            t.Append(rows)
+            t.Append(reorder(rows, order))
         }
     default:
         return fmt.Errorf("invalid type %T", v)
@@ -316,15 +1332,219 @@ func (t *Table) SetStructs(v interface{}) error {
     return nil
 }
 
-// Append row to table
-func (t *Table) Append(row []string) {
-    rowSize := len(t.headers)
-    if rowSize > t.colSize {
-        t.colSize = rowSize
-    }
+// A column of SetStructs: its header, the index path of its field, and its
+// alignment and position, if set by the tag
+type structColumn struct {
+    header  string
+    index   []int
+    align   int
+    aligned bool
+    order   int
+    ordered bool
+}
 
-    n := len(t.lines)
-    line := [][]string{}
+// Get the columns of struct type e, whose fields have index path index,
+// flattening the fields of embedded structs
+func structColumns(e reflect.Type, index []int) ([]structColumn, error) {
+    var columns []structColumn
+    for i := 0; i < e.NumField(); i++ {
+        f := e.Field(i)
+        tag := f.Tag.Get("tablewriter")
+        if tag == "-" {
+            continue
+        }
+        c, err := parseTag(tag)
+        if err != nil {
+            return nil, fmt.Errorf("field %s: %v", f.Name, err)
+        }
+        c.index = append(append([]int(nil), index...), i)
+        if ft := embeddedStruct(f); ft != nil && c.header == "" {
+            if c.ordered {
+                return nil, fmt.Errorf("field %s: order of an embedded struct", f.Name)
+            }
+            inner, err := structColumns(ft, c.index)
+            if err != nil {
+                return nil, err
+            }
+            columns = append(columns, inner...)
+            continue
+        }
+        if c.header == "" {
+            c.header = f.Name
+        }
+        columns = append(columns, c)
+    }
+    return columns, nil
+}
+
+// Put the columns with an order at their position, and the others in the
+// remaining positions in declaration order
+func orderColumns(columns []structColumn) ([]structColumn, error) {
+    ordered := make([]structColumn, len(columns))
+    placed := make([]bool, len(columns))
+    for _, c := range columns {
+        if !c.ordered {
+            continue
+        }
+        if c.order < 0 || c.order >= len(columns) {
+            return nil, fmt.Errorf("column %q: order %d out of range", c.header, c.order)
+        }
+        if placed[c.order] {
+            return nil, fmt.Errorf("column %q: order %d already taken by column %q", c.header, c.order, ordered[c.order].header)
+        }
+        ordered[c.order] = c
+        placed[c.order] = true
+    }
+    k := 0
+    for _, c := range columns {
+        if c.ordered {
+            continue
+        }
+        for placed[k] {
+            k++
+        }
+        ordered[k] = c
+        placed[k] = true
+    }
+    return ordered, nil
+}
+
+// Get the struct type of an embedded field to flatten, or nil
+func embeddedStruct(f reflect.StructField) reflect.Type {
+    if !f.Anonymous {
+        return nil
+    }
+    ft := f.Type
+    if ft.Kind() == reflect.Ptr {
+        ft = ft.Elem()
+    }
+    stringer := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+    if ft.Kind() != reflect.Struct || ft.Implements(stringer) || reflect.PtrTo(ft).Implements(stringer) {
+        return nil
+    }
+    return ft
+}
+
+// Get the field of struct v at index path index, the zero Value when an
+// embedded pointer on the way is nil
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+    for k, i := range index {
+        if k > 0 && v.Kind() == reflect.Ptr {
+            if v.IsNil() {
+                return reflect.Value{}
+            }
+            v = v.Elem()
+        }
+        v = v.Field(i)
+    }
+    return v
+}
+
+// Set Column Order
+// This pins the order of the columns created by SetStructs by header name.
+// The named columns come first, in the given order, followed by the other
+// columns in declaration order. Naming an unknown column makes SetStructs
+// return an error.
+func (t *Table) SetColumnOrder(names []string) {
+    t.columnNames = names
+}
+
+// Resolve the position of each header under the pinned column order
+func (t *Table) columnOrder(headers []string) ([]int, error) {
+    order := make([]int, 0, len(headers))
+    used := make(map[int]bool)
+    for _, name := range t.columnNames {
+        found := false
+        for i, header := range headers {
+            if header == name && !used[i] {
+                order = append(order, i)
+                used[i] = true
+                found = true
+                break
+            }
+        }
+        if !found {
+            return nil, fmt.Errorf("unknown column %q", name)
+        }
+    }
+    for i := range headers {
+        if !used[i] {
+            order = append(order, i)
+        }
+    }
+    return order, nil
+}
+
+// Get the text of a struct field, from its String method if it has one
+func fieldText(f reflect.Value) string {
+    f = reflect.Indirect(f)
+    if f.Kind() == reflect.Ptr {
+        f = f.Elem()
+    }
+    if !f.IsValid() {
+        return "nil"
+    }
+    if s, ok := f.Interface().(fmt.Stringer); ok {
+        return s.String()
+    }
+    return fmt.Sprint(f)
+}
+
+// Parse a tablewriter struct tag, the header followed by options such as
+// "Amount,align=right,order=2". Options other than align and order are
+// ignored.
+func parseTag(tag string) (structColumn, error) {
+    parts := strings.Split(tag, ",")
+    c := structColumn{header: parts[0]}
+    for _, opt := range parts[1:] {
+        opt = strings.TrimSpace(opt)
+        if value := strings.TrimPrefix(opt, "order="); value != opt {
+            order, err := strconv.Atoi(value)
+            if err != nil {
+                return structColumn{}, fmt.Errorf("invalid order %q", value)
+            }
+            c.order, c.ordered = order, true
+            continue
+        }
+        value := strings.TrimPrefix(opt, "align=")
+        if value == opt {
+            continue
+        }
+        switch value {
+        case "default":
+            c.align = ALIGN_DEFAULT
+        case "left":
+            c.align = ALIGN_LEFT
+        case "right":
+            c.align = ALIGN_RIGHT
+        case "center":
+            c.align = ALIGN_CENTER
+        default:
+            return structColumn{}, fmt.Errorf("invalid align %q", value)
+        }
+        c.aligned = true
+    }
+    return c, nil
+}
+
+// Reorder values by the positions in order
+func reorder(values []string, order []int) []string {
+    out := make([]string, len(order))
+    for i, j := range order {
+        out[i] = values[j]
+    }
+    return out
+}
+
+// Append row to table
+func (t *Table) Append(row []string) {
+    rowSize := len(t.headers)
+    if rowSize > t.colSize {
+        t.colSize = rowSize
+    }
+
+    n := len(t.lines)
+    line := [][]string{}
     for i, v := range row {
 
         // Detect string  width
@@ -336,6 +1556,7 @@ func (t *Table) Append(row []string) {
         line = append(line, out)
     }
     t.lines = append(t.lines, line)
+    t.rows = append(t.rows, append([]string(nil), row...))
 }
 
 // Append row to table with color attributes
@@ -354,15 +1575,52 @@ func (t *Table) Rich(row []string, colors []Colors) {
         // Break strings into words
         out := t.parseDimension(v, i, n)
 
-        if len(colors) > i {
-            color := colors[i]
-            out[0] = format(out[0], color)
-        }
-
         // Append broken words
         line = append(line, out)
     }
     t.lines = append(t.lines, line)
+    t.rows = append(t.rows, append([]string(nil), row...))
+
+    // The colors are applied to every line of the cells when printing
+    if t.cellColors == nil {
+        t.cellColors = make(map[int][]Colors)
+    }
+    t.cellColors[n] = colors
+}
+
+// Set the content of a cell appended earlier
+// The text is parsed the same way as appended cells. Column widths and row
+// heights only grow: they are not recomputed when the replaced content was
+// the widest or tallest.
+func (t *Table) SetCell(row, col int, text string) error {
+    if row < 0 || row >= len(t.lines) {
+        return fmt.Errorf("row %d out of range", row)
+    }
+    if col < 0 || col >= len(t.lines[row]) {
+        return fmt.Errorf("column %d out of range", col)
+    }
+    t.lines[row][col] = t.parseDimension(text, col, row)
+    if col < len(t.rows[row]) {
+        t.rows[row][col] = text
+    }
+    return nil
+}
+
+// Set the height of a row appended earlier, in lines
+// The height is kept across renders, even when the content of the row is
+// taller, in which case the extra lines are not printed.
+func (t *Table) SetRowHeight(row, lines int) error {
+    if row < 0 || row >= len(t.lines) {
+        return fmt.Errorf("row %d out of range", row)
+    }
+    if lines < 1 {
+        return fmt.Errorf("height %d out of range", lines)
+    }
+    if t.rowHeights == nil {
+        t.rowHeights = make(map[int]int)
+    }
+    t.rowHeights[row] = lines
+    return nil
 }
 
 // Allow Support for Bulk Append
@@ -373,6 +1631,114 @@ func (t *Table) AppendBulk(rows [][]string) {
     }
 }
 
+// Append the rows received from ch
+// This blocks until ch is closed, appending each row as it is received.
+// Returns the number of rows appended
+func (t *Table) AppendFromChan(ch <-chan []string) int {
+    n := 0
+    for row := range ch {
+        t.Append(row)
+        n++
+    }
+    return n
+}
+
+// Append a blank spacer row of the given number of lines
+// The row spans the columns known so far and has no content, so it groups
+// the rows around it without drawing a line.
+func (t *Table) AppendSpacer(lines int) {
+    if lines < 1 {
+        return
+    }
+    cols := len(t.cs)
+    if t.colSize > cols {
+        cols = t.colSize
+    }
+
+    n := len(t.lines)
+    t.lines = append(t.lines, make([][]string, cols))
+    t.rows = append(t.rows, nil)
+    t.rs[n] = lines
+    if t.spacers == nil {
+        t.spacers = make(map[int]bool)
+    }
+    t.spacers[n] = true
+}
+
+// Check whether row i is a spacer appended with AppendSpacer, rather than
+// a row without cells
+func (t *Table) isSpacer(i int) bool {
+    return t.spacers[i]
+}
+
+// Add a named column to the header
+// Rows can then be appended by column name with AppendNamed. Names must be
+// unique: an error is returned, and no column added, for a name already
+// added.
+func (t *Table) AddColumn(name string) error {
+    if _, ok := t.columnIndex[name]; ok {
+        return fmt.Errorf("duplicate column %q", name)
+    }
+    if t.columnIndex == nil {
+        t.columnIndex = make(map[string]int)
+    }
+    i := len(t.headers)
+    t.columnIndex[name] = i
+    t.headerKeys = append(t.headerKeys, name)
+    t.headers = append(t.headers, t.parseDimension(name, i, headerRowIdx))
+    t.colSize = len(t.headers)
+    return nil
+}
+
+// Set Ignore Unknown Columns
+// This would enable / disable ignoring the names passed to AppendNamed that
+// are not columns, instead of returning an error
+func (t *Table) SetIgnoreUnknownColumns(ignore bool) {
+    t.ignoreUnknown = ignore
+}
+
+// Append row to table by column name
+// Columns missing from the row are left empty.
+func (t *Table) AppendNamed(row map[string]string) error {
+    cells := make([]string, len(t.headers))
+    var unknown []string
+    for name, v := range row {
+        i, ok := t.columnIndex[name]
+        if !ok {
+            unknown = append(unknown, name)
+            continue
+        }
+        cells[i] = v
+    }
+    if len(unknown) > 0 && !t.ignoreUnknown {
+        sort.Strings(unknown)
+        return fmt.Errorf("unknown columns %q", unknown)
+    }
+    t.Append(cells)
+    return nil
+}
+
+// Append row to table only if cond is true
+// Returns whether the row was appended
+func (t *Table) AppendIf(cond bool, row []string) bool {
+    if cond {
+        t.Append(row)
+    }
+    return cond
+}
+
+// Append the rows for which keep returns true
+// Returns the number of rows appended
+func (t *Table) AppendBulkIf(rows [][]string, keep func(row []string) bool) int {
+    n := 0
+    for _, row := range rows {
+        if t.AppendIf(keep(row), row) {
+            n++
+        }
+    }
+    return n
+}
+
 // NumLines to get the number of lines
 func (t *Table) NumLines() int {
     return len(t.lines)
@@ -381,18 +1747,25 @@ func (t *Table) NumLines() int {
 // Clear rows
 func (t *Table) ClearRows() {
     t.lines = [][][]string{}
+    t.rows = [][]string{}
+    t.cellColors = nil
+    t.rowHeights = nil
+    t.spacers = nil
 }
 
 // Print line based on row width
 func (t *Table) printLine(nl bool, firstRow bool, lastRow bool) {
+    t.printSpanLine(nl, firstRow, lastRow, false, false)
+}
 
-    switch {
-    case firstRow:
-        fmt.Fprint(t.out, CENTER_ES)
-    case lastRow:
-        fmt.Fprint(t.out, CENTER_NE)
-    default:
-        fmt.Fprint(t.out, CENTER_NES)
+// Print line based on row width, without the column separators inside the
+// header cells of the heading above or below it
+func (t *Table) printSpanLine(nl bool, firstRow bool, lastRow bool, headingAbove bool, headingBelow bool) {
+
+    if t.borders.Left {
+        fmt.Fprint(t.out, t.style.junction(!firstRow, !lastRow, false, true))
+    } else {
+        fmt.Fprint(t.out, t.style.dim()+t.style.Row)
     }
     for i := 0; i < len(t.cs); i++ {
 
@@ -400,28 +1773,90 @@ func (t *Table) printLine(nl bool, firstRow bool, lastRow bool) {
 
         v := t.cs[i]
         fmt.Fprintf(t.out, "%s%s%s",
-            ROW,
-            strings.Repeat(string(ROW), v),
-            ROW)
+            t.style.Row,
+            strings.Repeat(t.style.Row, v),
+            t.style.Row)
 
         switch {
-        case lastCol && firstRow:
-            fmt.Fprint(t.out, CENTER_SW)
-        case lastCol && lastRow:
-            fmt.Fprint(t.out, CENTER_WN)
+        case lastCol && !t.borders.Right:
+            fmt.Fprint(t.out, t.style.Row)
         case lastCol:
-            fmt.Fprint(t.out, CENTER_NSW)
-        case firstRow:
-            fmt.Fprint(t.out, CENTER_ESW)
-        case lastRow:
-            fmt.Fprint(t.out, CENTER_NEW)
+            fmt.Fprint(t.out, t.style.junction(!firstRow, !lastRow, true, false))
+        case !t.colLine:
+            fmt.Fprint(t.out, t.style.Row)
         default:
-            fmt.Fprint(t.out, CENTER_ALL)
+            up := !firstRow && !(headingAbove && t.insideHeaderSpan(i))
+            down := !lastRow && !(headingBelow && t.insideHeaderSpan(i))
+            fmt.Fprint(t.out, t.style.junction(up, down, true, true))
         }
     }
     if nl {
-        fmt.Fprint(t.out, t.newLine)
+        fmt.Fprint(t.out, t.lineEnd())
+    }
+}
+
+// Resolve the glyph where a line crosses a column separator, from the
+// segments joining there: up and down along the separator, left and right
+// along the line. Glyphs at the left end of a line start the dim color of
+// the line.
+func (s BorderStyle) junction(up, down, left, right bool) string {
+    switch {
+    case !left && !right:
+        if up || down {
+            return s.column()
+        }
+        return SPACE
+    case !up && !down:
+        return s.Row
+    case up && down && left && right:
+        return s.CenterAll
+    case up && down && right:
+        return s.dim() + s.CenterNES
+    case up && down:
+        return s.CenterNSW
+    case down && left && right:
+        return s.CenterESW
+    case up && left && right:
+        return s.CenterNEW
+    case down && right:
+        return s.dim() + s.CenterES
+    case up && right:
+        return s.dim() + s.CenterNE
+    case down:
+        return s.CenterSW
+    default:
+        return s.CenterWN
+    }
+}
+
+// Return the replacer of the glyphs of the style with lookalikes: box
+// drawing glyphs with ASCII ones, and the column separator with "|", or
+// "¦" when it is "|". Other ASCII glyphs, such as "-" and "+", are left
+// as they are, as they are common in data.
+func (s BorderStyle) escaper() *strings.Replacer {
+    var pairs []string
+    for _, g := range []string{s.Column, s.Row, s.CenterAll, s.CenterNES, s.CenterNSW,
+        s.CenterNEW, s.CenterESW, s.CenterNE, s.CenterWN, s.CenterSW, s.CenterES} {
+        if r, ok := asciiGlyphs[g]; ok {
+            pairs = append(pairs, g, r)
+        } else if g == s.Column && g != "" {
+            pairs = append(pairs, g, ConditionString(g == "|", "¦", "|"))
+        }
     }
+    return strings.NewReplacer(pairs...)
+}
+
+// Return the escape sequence starting the dim color of the lines, if any
+func (s BorderStyle) dim() string {
+    return ConditionString(s.Dim, "\x1b[2m", "")
+}
+
+// Return the column separator of the rows
+func (s BorderStyle) column() string {
+    if s.Dim {
+        return "\x1b[2m" + s.Column + "\x1b[0m"
+    }
+    return s.Column
 }
 
 // Print line based on row width with our without cell separator
@@ -435,23 +1870,21 @@ func (t *Table) printLineOptionalCellSeparators(nl bool, displayCellSeparator []
         nextHasBorder = i > len(displayCellSeparator) || displayCellSeparator[i]
 
         switch {
-        case nextHasBorder && lastHasBorder:
-            fmt.Fprint(t.out, CENTER_ALL)
-        case nextHasBorder:
-            fmt.Fprint(t.out, CENTER_NES)
-        case lastHasBorder:
-            fmt.Fprint(t.out, CENTER_NSW)
+        case i == 0 && !t.borders.Left:
+            fmt.Fprint(t.out, ConditionString(nextHasBorder, t.style.dim()+t.style.Row, SPACE))
+        case i > 0 && !t.colLine:
+            fmt.Fprint(t.out, ConditionString(nextHasBorder, t.style.Row, SPACE))
         default:
-            fmt.Fprint(t.out, COLUMN)
+            fmt.Fprint(t.out, t.style.junction(true, true, lastHasBorder, nextHasBorder))
         }
 
         v := t.cs[i]
         if nextHasBorder {
             // Display the cell separator
             fmt.Fprintf(t.out, "%s%s%s",
-                ROW,
-                strings.Repeat(string(ROW), v),
-                ROW)
+                t.style.Row,
+                strings.Repeat(t.style.Row, v),
+                t.style.Row)
         } else {
             // Don't display the cell separator for this cell
             fmt.Fprintf(t.out, "%s",
@@ -460,21 +1893,29 @@ func (t *Table) printLineOptionalCellSeparators(nl bool, displayCellSeparator []
 
         lastHasBorder = nextHasBorder
     }
-    switch {
-    case lastHasBorder:
-        fmt.Fprint(t.out, CENTER_NSW)
-    default:
-        fmt.Fprint(t.out, COLUMN)
+    if t.borders.Right {
+        fmt.Fprint(t.out, t.style.junction(true, true, lastHasBorder, false))
+    } else {
+        fmt.Fprint(t.out, ConditionString(lastHasBorder, t.style.Row, SPACE))
     }
     if nl {
-        fmt.Fprint(t.out, t.newLine)
+        fmt.Fprint(t.out, t.lineEnd())
+    }
+}
+
+// Return the separator printed before column y of a row, the left border
+// for the first column
+func (t *Table) columnSeparator(y int) string {
+    if y == 0 {
+        return ConditionString(t.borders.Left, t.style.column(), SPACE)
     }
+    return ConditionString(t.colLine, t.style.column(), SPACE)
 }
 
 // Return the PadRight function if align is left, PadLeft if align is right,
-// and Pad by default
-func pad(align int) func(string, string, int) string {
-    padFunc := Pad
+// and center by default
+func (t *Table) pad(align int) func(string, string, int) string {
+    padFunc := t.center
     switch align {
     case ALIGN_LEFT:
         padFunc = PadRight
@@ -484,6 +1925,142 @@ func pad(align int) func(string, string, int) string {
     return padFunc
 }
 
+// Check whether a cell is right aligned as a number by default. The decision
+// is made once per cell from its first non-empty line, so that every line of
+// a multi-line cell is aligned the same way.
+func (t *Table) isNumericCell(cell []string) bool {
+    if !t.autoAlignNum {
+        return false
+    }
+    for _, line := range cell {
+        line = strings.TrimSpace(line)
+        if line == "" {
+            continue
+        }
+        return t.isNumeric(line)
+    }
+    return false
+}
+
+// Check whether str, trimmed of white space, is a number: a decimal or a
+// percentage, or a match of the patterns set by SetNumericPatterns. This is
+// the one test of numbers for aligning, formatting, humanizing and summing.
+func (t *Table) isNumeric(str string) bool {
+    str = strings.TrimSpace(str)
+    if len(t.numericPatterns) == 0 {
+        return decimal.MatchString(str) || percent.MatchString(str)
+    }
+    for _, p := range t.numericPatterns {
+        if p.MatchString(str) {
+            return true
+        }
+    }
+    return false
+}
+
+// Resolve the alignment of the header of column y
+func (t *Table) headerAlignment(y int) int {
+    if align, ok := t.sectionAlign[SectionHeader][y]; ok {
+        return align
+    }
+    if !t.hdrFollowAlign {
+        return t.hAlign
+    }
+    align := t.align
+    if y < len(t.columnsAlign) {
+        align = t.columnsAlign[y]
+    }
+    if align != ALIGN_DEFAULT {
+        return align
+    }
+    if t.isNumericColumn(y) {
+        return ALIGN_RIGHT
+    }
+    return ALIGN_LEFT
+}
+
+// Check whether every non-empty cell of column y is right aligned as a number
+func (t *Table) isNumericColumn(y int) bool {
+    numeric := false
+    for _, line := range t.lines {
+        if y >= len(line) || strings.TrimSpace(strings.Join(line[y], "")) == "" {
+            continue
+        }
+        if !t.isNumericCell(line[y]) {
+            return false
+        }
+        numeric = true
+    }
+    return numeric
+}
+
+// Format a line of header text for column y, or header cell y when the
+// header has spans
+func (t *Table) formatHeader(y int, h string) string {
+    styled := t.autoFmt && t.hdrStyle
+    if _, n := t.headerSpan(y); n > 1 {
+        styled = t.autoFmt && t.groupHdrStyle
+    }
+    switch {
+    case t.headerTransform != nil:
+        h = t.headerTransform(y, h)
+    case styled:
+        h = TitleLanguage(h, t.caseLang)
+    }
+    if styled {
+        h = format(h, t.headerAttr)
+    }
+    return h
+}
+
+// Grow the columns to fit headers changed by the header transform
+func (t *Table) fitHeaders() {
+    if g := t.groupHeading(); g != nil {
+        g.fitHeaderSpans()
+    }
+    if len(t.headerSpans) > 0 {
+        t.fitHeaderSpans()
+        return
+    }
+    if t.headerTransform == nil {
+        return
+    }
+    for y, lines := range t.headers {
+        for _, h := range lines {
+            if w := DisplayWidth(t.formatHeader(y, h)); w > t.cs[y] {
+                t.cs[y] = w
+            }
+        }
+    }
+}
+
+// Grow the last column of each header cell so that the columns it covers
+// fit it
+func (t *Table) fitHeaderSpans() {
+    for i, lines := range t.headers {
+        y, n := t.headerSpan(i)
+        for k := y; k < y+n; k++ {
+            if _, ok := t.cs[k]; !ok {
+                t.cs[k] = 0
+            }
+        }
+        for _, h := range lines {
+            if w := DisplayWidth(t.formatHeader(i, h)); w > t.spanWidth(y, n) {
+                t.cs[y+n-1] += w - t.spanWidth(y, n)
+            }
+        }
+    }
+}
+
+// Print the top border, whose junctions follow the first row of the heading
+func (t *Table) printTopLine() {
+    top := t
+    if g := t.groupHeading(); g != nil {
+        top = g
+    }
+    top.printSpanLine(true, true, false, false, len(t.headers) > 0)
+}
+
 // Print heading information
 func (t *Table) printHeading() {
     // Check if headers is available
@@ -491,12 +2068,22 @@ func (t *Table) printHeading() {
         return
     }
 
+    // The row of SetHeaderSpan comes first, with a line beneath
+    if g := t.groupHeading(); g != nil {
+        g.printHeadingText()
+        g.printSpanLine(true, false, false, true, false)
+    }
+    t.printHeadingText()
+    if t.hdrLine {
+        t.printSpanLine(true, false, false, true, false)
+    }
+}
+
+// Print the lines of the heading text, without any line around them
+func (t *Table) printHeadingText() {
     // Identify last column
     end := len(t.cs) - 1
 
-    // Get pad function
-    padFunc := pad(t.hAlign)
-
     // Checking for ANSI escape sequences for header
     is_esc_seq := false
     if len(t.headerParams) > 0 {
@@ -511,32 +2098,44 @@ func (t *Table) printHeading() {
         // Check if border is set
         // Replace with space if not set
         if !t.noWhiteSpace {
-            fmt.Fprint(t.out, COLUMN)
+            fmt.Fprint(t.out, ConditionString(t.borders.Left, t.style.column(), SPACE))
         }
 
-        for y := 0; y <= end; y++ {
-            v := t.cs[y]
+        // Print each header cell, one per column unless spanning columns
+        for i := 0; ; i++ {
+            y, n := t.headerSpan(i)
+            if y > end {
+                break
+            }
+            v := t.spanWidth(y, n)
+            padFunc := t.pad(t.headerAlignment(y))
+            if n > 1 {
+                padFunc = t.center
+            }
             h := ""
 
-            if y < len(t.headers) && x < len(t.headers[y]) {
-                h = t.headers[y][x]
+            if i < len(t.headers) && x < len(t.headers[i]) {
+                h = t.escapeGlyphs(t.headers[i][x])
             }
-            if t.autoFmt {
-                h = fmt.Sprintf("\x1b[1m%s\x1b[0m", Title(h))
-            }
-            pad := COLUMN
+            h = t.formatHeader(i, h)
+            pad := t.style.column()
             if t.noWhiteSpace {
                 pad = t.tablePadding
+            } else if y+n-1 >= end && !t.borders.Right {
+                pad = SPACE
+            } else if y+n-1 < end && !t.colLine {
+                pad = SPACE
             }
             if is_esc_seq {
                 if !t.noWhiteSpace {
                     fmt.Fprintf(t.out, " %s %s",
                         format(padFunc(h, SPACE, v),
-                            t.headerParams[y]), pad)
+                            t.headerParams[i]), pad)
                 } else {
-                    fmt.Fprintf(t.out, "%s %s",
+                    // Like the data, only the padding follows the cell
+                    fmt.Fprintf(t.out, "%s%s",
                         format(padFunc(h, SPACE, v),
-                            t.headerParams[y]), pad)
+                            t.headerParams[i]), pad)
                 }
             } else {
                 if !t.noWhiteSpace {
@@ -552,11 +2151,27 @@ func (t *Table) printHeading() {
             }
         }
         // Next line
-        fmt.Fprint(t.out, t.newLine)
+        fmt.Fprint(t.out, t.lineEnd())
     }
-    if t.hdrLine {
-        t.printLine(true, false, false)
+}
+
+// Check whether the heading is repeated below the rows
+func (t *Table) hasBottomHeading() bool {
+    return t.hdrAtBottom && len(t.headers) > 0
+}
+
+// Check whether the summary row or the heading is printed below the rows
+func (t *Table) hasRowsBelow() bool {
+    return t.hasSummary() || t.hasBottomHeading()
+}
+
+// Print the heading below the rows, separated from them like at the top
+func (t *Table) printBottomHeading() {
+    // A row line already separates the last row
+    if t.hdrLine && !t.rowLine {
+        t.printSpanLine(true, false, false, false, true)
     }
+    t.printHeadingText()
 }
 
 // Calculate the total number of characters in a row
@@ -565,19 +2180,265 @@ func (t Table) getTableWidth() int {
     for _, v := range t.cs {
         chars += v
     }
+    ncols := len(t.cs)
+    if ncols == 0 {
+        return 0
+    }
+
+    // Without white space every cell is simply followed by the padding.
+    if t.noWhiteSpace {
+        return chars + ncols*DisplayWidth(t.tablePadding)
+    }
+
+    // Add chars, spaces, seperators and borders to calculate the total
+    // width of the table.
+    spaces := ncols * 2
+    seps := (ncols - 1) * DisplayWidth(t.style.column())
+    left := DisplayWidth(ConditionString(t.borders.Left, t.style.column(), SPACE))
+    right := DisplayWidth(ConditionString(t.borders.Right, t.style.column(), SPACE))
+
+    return chars + spaces + seps + left + right
+}
+
+// CellHeight returns the number of lines text would occupy in a cell, as
+// the height of its row, with the current settings:
+//
+//   - the text is split into lines at each "\n", a trailing one included
+//   - without auto wrap, every line is a line of the cell
+//   - with auto wrap, the lines are wrapped to the width of the widest,
+//     at most the width set by SetColWidth
+//   - with reflow, the lines are first joined into one paragraph
+//   - without reflow, every line is a paragraph wrapped on its own, and the
+//     paragraph gap is inserted between two paragraphs that are not blank
+//   - the height is then capped to the maximum cell height, if any
+//
+// Column formatters and column overflows are not applied.
+func (t *Table) CellHeight(text string) int {
+    lines, _ := t.cellLines(text, -1, 0)
+    return len(lines)
+}
+
+// Width returns the number of characters in a rendered row of the table,
+// once its empty columns are hidden, its index column added and it is
+// fitted to the width, as set. Rows are shorter when their white space is
+// trimmed with SetTrailingSpace.
+func (t *Table) Width() int {
+    return t.rendered(t.out).getTableWidth()
+}
+
+// CellWidth returns the number of columns s would occupy in a cell, before
+// wrapping. It applies the same rules as the table: ANSI escape sequences
+// take no space, wide runes take two columns, tabs are expanded when a tab
+// width is set and take no space otherwise, and the widest line of a
+// multi-line string is used.
+func (t *Table) CellWidth(s string) int {
+    if t.tabWidth > 0 {
+        s = expandTabs(s, t.tabWidth)
+    }
+    width := 0
+    for _, line := range getLines(s) {
+        if w := DisplayWidth(line); w > width {
+            width = w
+        }
+    }
+    return width
+}
+
+// ComputeWidths returns the width of each column as it would be rendered,
+// without writing anything, including the index column and without the
+// hidden columns
+func (t *Table) ComputeWidths() []int {
+    l := t.rendered(t.out)
+    widths := make([]int, len(l.cs))
+    for i := range widths {
+        widths[i] = l.cs[i]
+    }
+    return widths
+}
+
+// Return a copy of the table laid out for rendering, with its own column
+// widths, row heights and rows, so that laying it out leaves the table
+// unchanged and it renders the same every time
+func (t *Table) laidOut() *Table {
+    l := *t
+    l.cs = make(map[int]int, len(t.cs))
+    for y, w := range t.cs {
+        l.cs[y] = w
+    }
+    l.rs = make(map[int]int, len(t.rs))
+    for i, h := range t.rs {
+        l.rs[i] = h
+    }
+    l.lines = make([][][]string, len(t.lines))
+    for i, columns := range t.lines {
+        l.lines[i] = append([][]string(nil), columns...)
+    }
+    l.columnsAlign = append([]int(nil), t.columnsAlign...)
+    l.layout()
+    return &l
+}
+
+// Finish the column widths that depend on settings applied at render time
+func (t *Table) layout() {
+    t.glyphEscaper = t.style.escaper()
+    t.fillAlignment(len(t.cs))
+    t.fitPercentiles()
+    for i, h := range t.rowHeights {
+        t.rs[i] = h
+    }
+    t.fitHeaders()
+    t.computeSummary()
+}
+
+// Shrink the columns with a width percentile to the percentile of the
+// widths of their cells, wrapping or truncating the wider cells again
+func (t *Table) fitPercentiles() {
+    for y, p := range t.colPercentile {
+        var widths []int
+        for _, columns := range t.lines {
+            if y < len(columns) {
+                widths = append(widths, DisplayWidth(strings.Join(columns[y], " ")))
+            }
+        }
+        if len(widths) == 0 {
+            continue
+        }
+        sort.Ints(widths)
+        n := int(math.Ceil(p / 100 * float64(len(widths))))
+        if n < 1 {
+            n = 1
+        } else if n > len(widths) {
+            n = len(widths)
+        }
+        width := widths[n-1]
+        if w := t.floorWidths[y]; w > width {
+            width = w
+        }
+        if width >= t.cs[y] {
+            continue
+        }
+
+        // The header and the words longer than the percentile still fit.
+        max := width
+        if y < len(t.headers) {
+            for _, h := range t.headers[y] {
+                if w := DisplayWidth(h); w > max {
+                    max = w
+                }
+            }
+        }
+        for i, columns := range t.lines {
+            if y >= len(columns) || cellWidth(columns[y]) <= width {
+                continue
+            }
+            cell := t.refit(strings.Join(columns[y], " "), i, y, width)
+            if w := cellWidth(cell); w > max {
+                max = w
+            }
+            if len(cell) > t.rs[i] {
+                t.rs[i] = len(cell)
+            }
+            columns[y] = cell
+        }
+        t.cs[y] = max
+    }
+}
 
-    // Add chars, spaces, seperators to calculate the total width of the table.
-    // ncols := t.colSize
-    // spaces := ncols * 2
-    // seps := ncols + 1
+// Wrap or truncate str again to width, as parseDimension does for column y
+// of row rowIdx
+func (t *Table) refit(str string, rowIdx, y, width int) []string {
+    if overflow, ok := t.columnOverflow[y]; ok && overflow == OverflowTruncate {
+        return []string{t.truncateCell(str, rowIdx, y, width)}
+    }
+    if t.wrapMode == WrapHard {
+        str = breakWords(str, width)
+    }
+    cell, _ := WrapStringTabs(str, width, t.tabWidth)
+    if t.maxCellHeight > 0 && len(cell) > t.maxCellHeight {
+        cell = cell[:t.maxCellHeight]
+        last := len(cell) - 1
+        cell[last] = truncate(cell[last]+ELLIPSIS, width, ELLIPSIS)
+    }
+    return cell
+}
 
-    return (chars + (3 * t.colSize) + 2)
+// Get the width of the widest line of a cell
+func cellWidth(cell []string) int {
+    max := 0
+    for _, line := range cell {
+        if w := DisplayWidth(line); w > max {
+            max = w
+        }
+    }
+    return max
 }
 
 func (t Table) printRows() {
-    for i, lines := range t.lines {
-        t.printRow(lines, i, i == len(t.lines)-1)
+    rows := t.visibleRows()
+    for k, i := range rows {
+        if k > 0 && t.isNewGroup(rows[k-1], i) {
+            t.printLine(true, false, false)
+        }
+        t.printRow(t.lines[i], i, k == len(rows)-1 && !t.hasRowsBelow())
+        if t.rowRenderedHook != nil {
+            t.rowRenderedHook(i)
+        }
+    }
+}
+
+// Get the indexes of the rows to print, without the blank rows removed or
+// collapsed
+func (t *Table) visibleRows() []int {
+    if t.pageRows != nil {
+        return t.pageRows
+    }
+    rows := make([]int, 0, len(t.lines))
+    for i := range t.lines {
+        if t.isBlankRow(i) {
+            if t.removeBlankRows {
+                continue
+            }
+            if t.collapseBlankRows && len(rows) > 0 && t.isBlankRow(rows[len(rows)-1]) {
+                continue
+            }
+        }
+        rows = append(rows, i)
+    }
+    return rows
+}
+
+// Check whether row i is blank, which is when it has at least one cell with
+// content and all of its cells hold only white space. Spacers appended with
+// AppendSpacer have no cell with content, so they are never blank.
+func (t *Table) isBlankRow(i int) bool {
+    blank := false
+    for _, cell := range t.lines[i] {
+        if len(cell) == 0 {
+            continue
+        }
+        if strings.TrimSpace(strings.Join(cell, "")) != "" {
+            return false
+        }
+        blank = true
+    }
+    return blank
+}
+
+// Check whether row i starts a new group, which is when the value of the
+// group separator column differs from the row prev printed before it
+func (t *Table) isNewGroup(prev, i int) bool {
+    if t.groupCol < 0 || t.rowLine {
+        return false
     }
+    return t.cellText(prev, t.groupCol) != t.cellText(i, t.groupCol)
+}
+
+// Get the text of cell y of row i, with the lines joined by spaces
+func (t *Table) cellText(i, y int) string {
+    if y >= len(t.lines[i]) {
+        return ""
+    }
+    return strings.Join(t.lines[i][y], " ")
 }
 
 func (t *Table) fillAlignment(num int) {
@@ -587,6 +2448,171 @@ func (t *Table) fillAlignment(num int) {
             t.columnsAlign[i] = t.align
         }
     }
+    for y, align := range t.columnsAlignMap {
+        if y < len(t.columnsAlign) {
+            t.columnsAlign[y] = align
+        }
+    }
+}
+
+// Pad a row with empty cells up to the number of columns when padding short
+// rows, without altering columns, which is shared with t.lines
+func (t *Table) padShortRow(columns [][]string) [][]string {
+    if !t.padShortRows || len(columns) >= len(t.cs) {
+        return columns
+    }
+    padded := make([][]string, len(t.cs))
+    copy(padded, columns)
+    return padded
+}
+
+// Pad a string in the center, giving the odd space to the side of the
+// center bias
+func (t *Table) center(s, pad string, width int) string {
+    return PadBias(s, pad, width, t.centerBias)
+}
+
+// Pad a line of cell y to the column width according to the alignment
+func (t *Table) alignCell(str string, rowIdx, y int, cell []string) string {
+    switch t.cellAlignment(rowIdx, y) {
+    case ALIGN_CENTER:
+        return t.center(str, SPACE, t.cs[y])
+    case ALIGN_RIGHT:
+        return PadLeft(str, SPACE, t.cs[y])
+    case ALIGN_LEFT:
+        return PadRight(str, SPACE, t.cs[y])
+    default:
+        if t.isBoolCell(y, cell) {
+            return t.center(str, SPACE, t.cs[y])
+        }
+        right := rowIdx == footerRowIdx || t.isNumericCell(cell) || t.isHumanizedCell(y, cell)
+        // Right to left, the sides are swapped.
+        if right != t.mirrored {
+            return PadLeft(str, SPACE, t.cs[y])
+        }
+        return PadRight(str, SPACE, t.cs[y])
+    }
+}
+
+// Check whether a cell of column y holds a glyph of SetColumnBool
+func (t *Table) isBoolCell(y int, cell []string) bool {
+    glyphs, ok := t.columnBools[y]
+    if !ok || len(cell) != 1 {
+        return false
+    }
+    return cell[0] == glyphs[0] || cell[0] == glyphs[1]
+}
+
+// Replace a boolean value with the glyph of SetColumnBool for column y
+func (t *Table) boolGlyph(y int, str string) string {
+    glyphs, ok := t.columnBools[y]
+    if !ok {
+        return str
+    }
+    trues, falses := t.trueValues, t.falseValues
+    if trues == nil && falses == nil {
+        trues, falses = []string{"true"}, []string{"false"}
+    }
+    value := strings.TrimSpace(str)
+    for _, v := range trues {
+        if strings.EqualFold(value, v) {
+            return glyphs[0]
+        }
+    }
+    for _, v := range falses {
+        if strings.EqualFold(value, v) {
+            return glyphs[1]
+        }
+    }
+    return str
+}
+
+// A mask of SetColumnMask
+type mask struct {
+    char     rune
+    keepLast int
+}
+
+// Mask str if column y is masked
+func (t *Table) maskCell(y int, str string) string {
+    m, ok := t.columnMasks[y]
+    if !ok {
+        return str
+    }
+    runes := []rune(strings.TrimSpace(str))
+    keep := m.keepLast
+    if keep >= len(runes) || keep < 0 {
+        keep = 0
+    }
+    return strings.Repeat(string(m.char), len(runes)-keep) + string(runes[len(runes)-keep:])
+}
+
+// Resolve the alignment of cell y of a data row, or of the footer row when
+// rowIdx is footerRowIdx
+func (t *Table) cellAlignment(rowIdx, y int) int {
+    section := SectionBody
+    if rowIdx == footerRowIdx {
+        section = SectionFooter
+    }
+    if align, ok := t.sectionAlign[section][y]; ok {
+        return align
+    }
+    if section == SectionFooter && t.fAlign != ALIGN_DEFAULT {
+        return t.fAlign
+    }
+    if align, ok := t.sectionAlign[SectionBody][y]; ok {
+        return align
+    }
+    if y >= len(t.columnsAlign) {
+        return t.align
+    }
+    return t.columnsAlign[y]
+}
+
+// Truncate str to width for cell y of a data row, keeping the side it is
+// aligned to visible
+func (t *Table) truncateCell(str string, rowIdx, y, width int) string {
+    align := t.cellAlignment(rowIdx, y)
+    if align == ALIGN_DEFAULT && t.isNumericCell([]string{str}) {
+        align = ALIGN_RIGHT
+    }
+    return truncateAligned(str, width, ELLIPSIS, align)
+}
+
+// Get line x of a cell of column y, or an empty line past its end. Numbers
+// aligned right by default are printed without the white space around them,
+// which is ignored to detect them, so that they line up.
+func (t *Table) cellLine(cell []string, rowIdx, y, x int) string {
+    if x >= len(cell) {
+        return ""
+    }
+    if t.cellAlignment(rowIdx, y) == ALIGN_DEFAULT && t.isNumericCell(cell) {
+        return t.escapeGlyphs(strings.TrimSpace(cell[x]))
+    }
+    return t.escapeGlyphs(cell[x])
+}
+
+// Replace the glyphs of the borders in a line of a cell, when escaping
+// them, so that they don't look like borders. This is done as the line is
+// printed, as the lookalikes are as wide as the glyphs.
+func (t *Table) escapeGlyphs(str string) string {
+    if !t.escapeBorders {
+        return str
+    }
+    r := t.glyphEscaper
+    if r == nil {
+        r = t.style.escaper()
+    }
+    return r.Replace(str)
+}
+
+// Get the colors given to Rich for cell y of a row, or else the colors of
+// column y
+func (t *Table) cellColor(rowIdx, y int) Colors {
+    if colors := t.cellColors[rowIdx]; y < len(colors) && len(colors[y]) > 0 {
+        return colors[y]
+    }
+    return t.columnColors[y]
 }
 
 // Print Row Information
@@ -595,19 +2621,9 @@ func (t *Table) fillAlignment(num int) {
 func (t *Table) printRow(columns [][]string, rowIdx int, last bool) {
     // Get Maximum Height
     max := t.rs[rowIdx]
+    columns = t.padShortRow(columns)
     total := len(columns)
 
-    // TODO Fix uneven col size
-    // if total < t.colSize {
-    //	for n := t.colSize - total; n < t.colSize ; n++ {
-    //		columns = append(columns, []string{SPACE})
-    //		t.cs[n] = t.mW
-    //	}
-    //}
-
-    // Pad Each Height
-    pads := []int{}
-
     // Checking for ANSI escape sequences for columns
     is_esc_seq := false
     if len(t.columnsParams) > 0 {
@@ -615,25 +2631,25 @@ func (t *Table) printRow(columns [][]string, rowIdx int, last bool) {
     }
     t.fillAlignment(total)
 
-    for i, line := range columns {
-        length := len(line)
-        pad := max - length
-        pads = append(pads, pad)
-        for n := 0; n < pad; n++ {
-            columns[i] = append(columns[i], "  ")
-        }
-    }
     //fmt.Println(max, "\n")
     for x := 0; x < max; x++ {
+        var line bytes.Buffer
         for y := 0; y < total; y++ {
 
             // Check if border is set
             if !t.noWhiteSpace {
-                fmt.Fprint(t.out, COLUMN)
-                fmt.Fprintf(t.out, SPACE)
+                fmt.Fprint(&line, t.columnSeparator(y))
             }
 
-            str := columns[y][x]
+            // Pad each height with empty lines, without altering columns,
+            // which is shared with t.lines
+            str := t.cellLine(columns[y], rowIdx, y, x)
+
+            // Embedding escape sequence with cell value
+            color := t.cellColor(rowIdx, y)
+            if !t.richPadding && x < len(columns[y]) {
+                str = format(str, color)
+            }
 
             // Embedding escape sequence with column value
             if is_esc_seq {
@@ -642,43 +2658,27 @@ func (t *Table) printRow(columns [][]string, rowIdx int, last bool) {
 
             // This would print alignment
             // Default alignment  would use multiple configuration
-            switch t.columnsAlign[y] {
-            case ALIGN_CENTER: //
-                fmt.Fprintf(t.out, "%s", Pad(str, SPACE, t.cs[y]))
-            case ALIGN_RIGHT:
-                fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
-            case ALIGN_LEFT:
-                fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-            default:
-                if decimal.MatchString(strings.TrimSpace(str)) || percent.MatchString(strings.TrimSpace(str)) {
-                    fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
-                } else {
-                    fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-
-                    // TODO Custom alignment per column
-                    //if max == 1 || pads[y] > 0 {
-                    //	fmt.Fprintf(t.out, "%s", Pad(str, SPACE, t.cs[y]))
-                    //} else {
-                    //	fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-                    //}
-
-                }
-            }
+            str = t.alignCell(str, rowIdx, y, columns[y])
             if !t.noWhiteSpace {
-                fmt.Fprintf(t.out, SPACE)
-            } else {
-                fmt.Fprintf(t.out, t.tablePadding)
+                str = SPACE + str + SPACE
+            }
+            if t.richPadding {
+                str = format(str, color)
+            }
+            fmt.Fprint(&line, str)
+            if t.noWhiteSpace {
+                fmt.Fprintf(&line, t.tablePadding)
             }
         }
         // Check if border is set
         // Replace with space if not set
         if !t.noWhiteSpace {
-            fmt.Fprint(t.out, COLUMN)
+            fmt.Fprint(&line, ConditionString(t.borders.Right, t.style.column(), SPACE))
         }
-        fmt.Fprint(t.out, t.newLine)
+        fmt.Fprint(t.out, t.stripeLine(rowIdx, line.String()), t.lineEnd())
     }
 
-    if t.rowLine {
+    if t.rowLine && (!last || t.borders.Bottom) {
         t.printLine(true, false, last)
     }
 }
@@ -688,19 +2688,28 @@ func (t *Table) printRowsMergeCells() {
     var previousLine []string
     var displayCellBorder []bool
     var tmpWriter bytes.Buffer
-    for i, lines := range t.lines {
+    rows := t.visibleRows()
+    for k, i := range rows {
+        newGroup := k > 0 && t.isNewGroup(rows[k-1], i)
+        // Cells are not merged across groups
+        if newGroup {
+            previousLine = nil
+        }
         // We store the display of the current line in a tmp writer, as we need to know which border needs to be print above
-        previousLine, displayCellBorder = t.printRowMergeCells(&tmpWriter, lines, i, previousLine)
-        if i > 0 { //We don't need to print borders above first line
-            if t.rowLine {
+        previousLine, displayCellBorder = t.printRowMergeCells(&tmpWriter, t.lines[i], i, previousLine)
+        if k > 0 { //We don't need to print borders above first line
+            if t.rowLine || newGroup {
                 t.printLineOptionalCellSeparators(true, displayCellBorder)
             }
         }
         tmpWriter.WriteTo(t.out)
+        if t.rowRenderedHook != nil {
+            t.rowRenderedHook(i)
+        }
     }
     //Print the end of the table
-    if t.rowLine {
-        t.printLine(true, false, true)
+    if t.rowLine && (t.borders.Bottom || t.hasRowsBelow()) {
+        t.printLine(true, false, !t.hasRowsBelow())
     }
 }
 
@@ -710,36 +2719,33 @@ func (t *Table) printRowsMergeCells() {
 func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx int, previousLine []string) ([]string, []bool) {
     // Get Maximum Height
     max := t.rs[rowIdx]
+    columns = t.padShortRow(columns)
     total := len(columns)
 
-    // Pad Each Height
-    pads := []int{}
-
     // Checking for ANSI escape sequences for columns
     is_esc_seq := false
     if len(t.columnsParams) > 0 {
         is_esc_seq = true
     }
-    for i, line := range columns {
-        length := len(line)
-        pad := max - length
-        pads = append(pads, pad)
-        for n := 0; n < pad; n++ {
-            columns[i] = append(columns[i], "  ")
-        }
-    }
 
     var displayCellBorder []bool
     t.fillAlignment(total)
     for x := 0; x < max; x++ {
+        var line bytes.Buffer
         for y := 0; y < total; y++ {
 
             // Check if border is set
-            fmt.Fprint(writer, COLUMN)
+            fmt.Fprint(&line, t.columnSeparator(y))
 
-            fmt.Fprintf(writer, SPACE)
+            // Pad each height with empty lines, without altering columns,
+            // which is shared with t.lines
+            str := t.cellLine(columns[y], rowIdx, y, x)
 
-            str := columns[y][x]
+            // Embedding escape sequence with cell value
+            color := t.cellColor(rowIdx, y)
+            if !t.richPadding && x < len(columns[y]) {
+                str = format(str, color)
+            }
 
             // Embedding escape sequence with column value
             if is_esc_seq {
@@ -763,6 +2769,7 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
                     // If this cell is identical to the one above but not empty, we don't display the border and keep the cell empty.
                     displayCellBorder = append(displayCellBorder, false)
                     str = ""
+                    color = nil
                 } else {
                     // First line or different content, keep the content and print the cell border
                     displayCellBorder = append(displayCellBorder, true)
@@ -771,26 +2778,16 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 
             // This would print alignment
             // Default alignment  would use multiple configuration
-            switch t.columnsAlign[y] {
-            case ALIGN_CENTER: //
-                fmt.Fprintf(writer, "%s", Pad(str, SPACE, t.cs[y]))
-            case ALIGN_RIGHT:
-                fmt.Fprintf(writer, "%s", PadLeft(str, SPACE, t.cs[y]))
-            case ALIGN_LEFT:
-                fmt.Fprintf(writer, "%s", PadRight(str, SPACE, t.cs[y]))
-            default:
-                if decimal.MatchString(strings.TrimSpace(str)) || percent.MatchString(strings.TrimSpace(str)) {
-                    fmt.Fprintf(writer, "%s", PadLeft(str, SPACE, t.cs[y]))
-                } else {
-                    fmt.Fprintf(writer, "%s", PadRight(str, SPACE, t.cs[y]))
-                }
+            str = SPACE + t.alignCell(str, rowIdx, y, columns[y]) + SPACE
+            if t.richPadding {
+                str = format(str, color)
             }
-            fmt.Fprintf(writer, SPACE)
+            fmt.Fprint(&line, str)
         }
         // Check if border is set
         // Replace with space if not set
-        fmt.Fprint(writer, COLUMN)
-        fmt.Fprint(writer, t.newLine)
+        fmt.Fprint(&line, ConditionString(t.borders.Right, t.style.column(), SPACE))
+        fmt.Fprint(writer, t.stripeLine(rowIdx, line.String()), t.lineEnd())
     }
 
     //The new previous line is the current one
@@ -803,12 +2800,81 @@ func (t *Table) printRowMergeCells(writer io.Writer, columns [][]string, rowIdx
 }
 
 func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
+    raw, maxWidth := t.cachedCellLines(str, colKey, rowKey)
+
+    // Store the new known maximum width.
+    v, ok := t.cs[colKey]
+    if !ok || v < maxWidth || v == 0 {
+        t.cs[colKey] = maxWidth
+    }
+
+    // Remember the number of lines for the row printer.
+    h := len(raw)
+    v, ok = t.rs[rowKey]
+
+    if !ok || v < h || v == 0 {
+        t.rs[rowKey] = h
+    }
+    //fmt.Printf("Raw %+v %d\n", raw, len(raw))
+    return raw
+}
+
+// Get the maximum width of a cell of column colKey and row rowKey
+// Headers have their own maximum when one is set.
+// Columns may have their own maximum, set by SetColMaxWidth.
+func (t *Table) wrapLimit(colKey, rowKey int) int {
+    limit := t.mW
+    if w, ok := t.colMaxWidths[colKey]; ok {
+        limit = w
+    }
+    if rowKey == headerRowIdx && t.hdrMW > 0 {
+        limit = t.hdrMW
+    }
+    return limit
+}
+
+// Get the lines of a cell of column colKey and row rowKey, and the width of
+// the widest, without sizing the column nor the row
+func (t *Table) cellLines(str string, colKey, rowKey int) ([]string, int) {
     var (
         raw      []string
         maxWidth int
     )
 
-    raw = getLines(str)
+    // Remove the invisible characters that would only take room.
+    if t.normalizeWidth {
+        str = stripZeroWidth(str, t.stripJoiners)
+    }
+
+    // Apply the value transformer, then the column formatters, to data
+    // cells.
+    if rowKey >= 0 {
+        if t.valueTransformer != nil {
+            str = t.valueTransformer(rowKey, colKey, str)
+        }
+        for _, f := range t.columnFormatters[colKey] {
+            str = f(str)
+        }
+        str = t.boolGlyph(colKey, str)
+        str = t.humanize(colKey, str)
+        if t.numberFormat != nil && t.isNumeric(str) {
+            str = t.numberFormat(strings.TrimSpace(str))
+        }
+        str = t.maskCell(colKey, str)
+    }
+
+    // Expand tabs before measuring the width.
+    if t.tabWidth > 0 {
+        str = expandTabs(str, t.tabWidth)
+    }
+
+    // Vertical headers are stacked one rune per line and never wrapped.
+    vertical := rowKey == headerRowIdx && t.headerVertical
+    if vertical {
+        raw = getRunes(str)
+    } else {
+        raw = getLines(str)
+    }
     maxWidth = 0
     for _, line := range raw {
         if w := DisplayWidth(line); w > maxWidth {
@@ -816,17 +2882,39 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
         }
     }
 
+    // If there's a maximum allowed width, use that.
+    limit := t.wrapLimit(colKey, rowKey)
+
+    // The column overflow, when set, overrides the global wrapping policy.
+    wrap := t.autoWrap && t.wrapMode != WrapNone
+    overflow, ok := t.columnOverflow[colKey]
+    if ok {
+        wrap = overflow == OverflowWrap
+    }
+
+    // If truncating, cut the cell to a single line fitting in the
+    // specified width.
+    if ok && overflow == OverflowTruncate && !vertical {
+        line := strings.Join(raw, " ")
+        if rowKey >= 0 {
+            line = t.truncateCell(line, rowKey, colKey, limit)
+        } else {
+            line = truncate(line, limit, ELLIPSIS)
+        }
+        raw = []string{line}
+        maxWidth = DisplayWidth(line)
+    }
+
     // If wrapping, ensure that all paragraphs in the cell fit in the
     // specified width.
-    if t.autoWrap {
-        // If there's a maximum allowed width for wrapping, use that.
-        if maxWidth > t.mW {
-            maxWidth = t.mW
+    if wrap && !vertical {
+        if maxWidth > limit {
+            maxWidth = limit
         }
 
         // In the process of doing so, we need to recompute maxWidth. This
         // is because perhaps a word in the cell is longer than the
-        // allowed maximum width in t.mW.
+        // allowed maximum width.
         newMaxWidth := maxWidth
         newRaw := make([]string, 0, len(raw))
 
@@ -835,14 +2923,19 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
             raw = []string{strings.Join(raw, " ")}
         }
         for i, para := range raw {
-            paraLines, _ := WrapString(para, maxWidth)
-            for _, line := range paraLines {
-                if w := DisplayWidth(line); w > newMaxWidth {
-                    newMaxWidth = w
-                }
+            if t.wrapMode == WrapHard {
+                para = breakWords(para, maxWidth)
+            }
+            paraLines, _, w := wrapStringTabs(para, maxWidth, t.tabWidth)
+            if w > newMaxWidth {
+                newMaxWidth = w
             }
-            if i > 0 {
-                newRaw = append(newRaw, " ")
+            // A blank line of the text separates the paragraphs instead
+            // of the gap.
+            if i > 0 && para != "" && raw[i-1] != "" {
+                for n := 0; n < t.paragraphGap; n++ {
+                    newRaw = append(newRaw, "")
+                }
             }
             newRaw = append(newRaw, paraLines...)
         }
@@ -850,19 +2943,14 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
         maxWidth = newMaxWidth
     }
 
-    // Store the new known maximum width.
-    v, ok := t.cs[colKey]
-    if !ok || v < maxWidth || v == 0 {
-        t.cs[colKey] = maxWidth
-    }
-
-    // Remember the number of lines for the row printer.
-    h := len(raw)
-    v, ok = t.rs[rowKey]
-
-    if !ok || v < h || v == 0 {
-        t.rs[rowKey] = h
+    // Cap the height of the cell, marking the cut with an ellipsis.
+    if t.maxCellHeight > 0 && len(raw) > t.maxCellHeight {
+        raw = raw[:t.maxCellHeight]
+        if w := DisplayWidth(ELLIPSIS); maxWidth < w {
+            maxWidth = w
+        }
+        last := len(raw) - 1
+        raw[last] = truncate(raw[last]+ELLIPSIS, maxWidth, ELLIPSIS)
     }
-    //fmt.Printf("Raw %+v %d\n", raw, len(raw))
-    return raw
+    return raw, maxWidth
 }
@@ -9,12 +9,18 @@ package tablewriter
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
 )
 
 func checkEqual(t *testing.T, got, want interface{}, msgs ...interface{}) {
@@ -1377,3 +1383,3151 @@ func TestStructs(t *testing.T) {
 		})
 	}
 }
+
+func TestParagraphGap(t *testing.T) {
+	for _, gap := range []int{0, 1, 2} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetReflowDuringAutoWrap(false)
+		table.SetParagraphGap(gap)
+		table.Append([]string{"first\nsecond"})
+		table.Render()
+
+		want := "┌────────┐\n" +
+			"│ first  │\n" +
+			strings.Repeat("│        │\n", gap) +
+			"│ second │\n" +
+			"└────────┘\n"
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, fmt.Sprintf("paragraph gap %d failed", gap))
+	}
+}
+
+func TestBOM(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.Append([]string{"a"})
+	table.Render()
+	if strings.HasPrefix(buf.String(), BOM) {
+		t.Error("BOM written by default")
+	}
+
+	buf.Reset()
+	table.SetBOM(true)
+	table.Render()
+	if !strings.HasPrefix(buf.String(), BOM) {
+		t.Errorf("BOM missing: %q", buf.String())
+	}
+	checkEqual(t, strings.Count(buf.String(), BOM), 1, "BOM written more than once")
+}
+
+func TestMaxCellHeight(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColWidth(10)
+	table.SetMaxCellHeight(2)
+	table.Append([]string{"one two three four five six"})
+	table.Append([]string{"short"})
+	table.Render()
+
+	want := `┌────────────┐
+│ one two    │
+│ three fou… │
+│ short      │
+└────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "max cell height failed")
+}
+
+func TestBorders(t *testing.T) {
+	// Each line of the expected output, indexed by [left][right].
+	lines := map[string][2][2]string{
+		"top": {
+			{"───────┬─────────\n", "───────┬────────┐\n"},
+			{"┌──────┬─────────\n", "┌──────┬────────┐\n"},
+		},
+		"header": {
+			{"  NAME │ RATING  \n", "  NAME │ RATING │\n"},
+			{"│ NAME │ RATING  \n", "│ NAME │ RATING │\n"},
+		},
+		"sep": {
+			{"───────┼─────────\n", "───────┼────────┤\n"},
+			{"├──────┼─────────\n", "├──────┼────────┤\n"},
+		},
+		"row": {
+			{"  A    │    500  \n", "  A    │    500 │\n"},
+			{"│ A    │    500  \n", "│ A    │    500 │\n"},
+		},
+		"bottom": {
+			{"───────┴─────────\n", "───────┴────────┘\n"},
+			{"└──────┴─────────\n", "└──────┴────────┘\n"},
+		},
+	}
+	idx := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	for i := 0; i < 16; i++ {
+		border := Border{Left: i&1 != 0, Right: i&2 != 0, Top: i&4 != 0, Bottom: i&8 != 0}
+		l, r := idx(border.Left), idx(border.Right)
+
+		want := ""
+		if border.Top {
+			want += lines["top"][l][r]
+		}
+		want += lines["header"][l][r] + lines["sep"][l][r] + lines["row"][l][r]
+		if border.Bottom {
+			want += lines["bottom"][l][r]
+		}
+
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetBorders(border)
+		table.SetHeader([]string{"Name", "Rating"})
+		table.Append([]string{"A", "500"})
+		table.Render()
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, fmt.Sprintf("border %+v failed", border))
+	}
+}
+
+func TestNoBorderMergeCells(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorder(false)
+	table.SetAutoMergeCells(true)
+	table.SetRowLine(true)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.AppendBulk([][]string{{"A", "500"}, {"A", "400"}})
+	table.Render()
+
+	want := `  NAME │ RATING  
+───────┼─────────
+  A    │    500  
+       ├─────────
+       │    400  
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "no border merge cells failed")
+}
+
+func TestPresets(t *testing.T) {
+	tests := []struct {
+		preset Preset
+		want   string
+	}{
+		{PresetPSQL, `  Name │ Rating  
+───────┼─────────
+  A    │    500  
+`},
+		{PresetMySQL, `┌──────┬────────┐
+│ Name │ Rating │
+├──────┼────────┤
+│ A    │    500 │
+└──────┴────────┘
+`},
+		{PresetMarkdown, `| Name | Rating |
+|------|--------|
+| A    |    500 |
+`},
+		{PresetBorderless, `Name  Rating
+A        500
+`},
+		{PresetCompact, `NAME RATING
+A       500
+`},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetPreset(tt.preset)
+		table.SetHeader([]string{"Name", "Rating"})
+		table.Append([]string{"A", "500"})
+		table.Render()
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), tt.want, fmt.Sprintf("preset %d failed", tt.preset))
+	}
+}
+
+func TestPresetMarkdown(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"a", "b"})
+	table.SetHeaderColor(Colors{Bold, FgRedColor}, Colors{FgBlueColor})
+	table.SetPreset(PresetMarkdown)
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want := "| a | b |\n|---|---|\n| 1 | 2 |\n"
+	checkEqual(t, buf.String(), want, "markdown preset failed")
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*Table)
+	}{
+		{"default", func(*Table) {}},
+		{"no border", func(table *Table) { table.SetBorder(false) }},
+		{"no left border", func(table *Table) { table.SetBorders(Border{Right: true}) }},
+		{"no white space", func(table *Table) {
+			table.SetPreset(PresetCompact)
+			table.SetTrailingSpace(true)
+		}},
+		{"wide padding", func(table *Table) {
+			table.SetPreset(PresetCompact)
+			table.SetTablePadding(" 漢 ")
+			table.SetTrailingSpace(true)
+		}},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		tt.setup(table)
+		table.SetHeader([]string{"Name", "Sign", "Rating"})
+		table.Append([]string{"A", "The Good", "500"})
+		table.Render()
+
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			checkEqual(t, table.Width(), DisplayWidth(line), tt.name+" width failed")
+		}
+	}
+}
+
+func TestRowRenderedHook(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoMergeCells(merge)
+		table.SetRowLine(true)
+		table.SetHeader([]string{"Name", "Sign"})
+		table.AppendBulk([][]string{{"A", "The Good"}, {"A", "The Bad"}, {"B", "The Ugly"}})
+
+		var rows []int
+		var lines []int
+		table.SetRowRenderedHook(func(rowIdx int) {
+			rows = append(rows, rowIdx)
+			lines = append(lines, strings.Count(buf.String(), "\n"))
+		})
+		table.Render()
+
+		checkEqual(t, rows, []int{0, 1, 2}, "row indexes failed")
+		if merge {
+			// The row line of merged cells is printed above each row.
+			checkEqual(t, lines, []int{4, 6, 8}, "lines written failed")
+		} else {
+			checkEqual(t, lines, []int{5, 7, 9}, "lines written failed")
+		}
+	}
+}
+
+func TestAutoAlignNumbers(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetAutoAlignNumbers(false)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.AppendBulk([][]string{{"A", "500"}, {"B", "12.5%"}})
+	table.Render()
+
+	want := `┌──────┬────────┐
+│ NAME │ RATING │
+├──────┼────────┤
+│ A    │ 500    │
+│ B    │ 12.5%  │
+└──────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "auto align numbers failed")
+}
+
+func TestAddOutput(t *testing.T) {
+	buf := &bytes.Buffer{}
+	text := &bytes.Buffer{}
+	csvOut := &bytes.Buffer{}
+	htmlOut := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.AddOutput(text, FormatText)
+	table.AddOutput(csvOut, FormatCSV)
+	table.AddOutput(htmlOut, FormatHTML)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+	if err := table.RenderErr(); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.Len() == 0 {
+		t.Fatal("nothing written to the table writer")
+	}
+	checkEqual(t, text.String(), buf.String(), "text output failed")
+	checkEqual(t, csvOut.String(), "Name,Sign\nA,The Good\n", "csv output failed")
+	want := "<table>\n<thead>\n<tr><th>Name</th><th>Sign</th></tr>\n</thead>\n" +
+		"<tbody>\n<tr><td>A</td><td>The Good</td></tr>\n</tbody>\n</table>\n"
+	checkEqual(t, htmlOut.String(), want, "html output failed")
+
+	// A failing output does not fail the table or the other outputs
+	buf.Reset()
+	csvOut.Reset()
+	table = NewWriter(buf)
+	table.AddOutput(&failingWriter{}, FormatHTML)
+	table.AddOutput(&failingWriter{}, FormatCSV)
+	table.AddOutput(csvOut, FormatCSV)
+	table.Append([]string{"A", "The Good"})
+	if err := table.RenderErr(); err != nil {
+		t.Errorf("render with a failing output failed: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Error("nothing written to the table writer with a failing output")
+	}
+	checkEqual(t, csvOut.String(), "A,The Good\n", "csv output next to a failing output failed")
+}
+
+func TestMultiLineCellAlignment(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Value"})
+	table.AppendBulk([][]string{{"A", "123\nabc"}, {"B", "abc\n123"}, {"C", "12345"}})
+	table.Render()
+
+	want := `┌──────┬───────┐
+│ NAME │ VALUE │
+├──────┼───────┤
+│ A    │   123 │
+│      │   abc │
+│ B    │ abc   │
+│      │ 123   │
+│ C    │ 12345 │
+└──────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "multi-line cell alignment failed")
+}
+
+func TestSetCell(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.AppendBulk([][]string{{"A", "The Good"}, {"B", "The Ugly"}})
+
+	checkEqual(t, table.SetCell(1, 1, "The Very\nBad Man"), nil, "set cell failed")
+	checkEqual(t, table.SetCell(0, 0, "A+"), nil, "set cell failed")
+	for _, idx := range [][2]int{{-1, 0}, {2, 0}, {0, -1}, {0, 2}} {
+		if err := table.SetCell(idx[0], idx[1], "x"); err == nil {
+			t.Errorf("expected error for cell %v", idx)
+		}
+	}
+	table.Render()
+
+	want := `┌──────┬──────────┐
+│ NAME │   SIGN   │
+├──────┼──────────┤
+│ A+   │ The Good │
+│ B    │ The Very │
+│      │ Bad Man  │
+└──────┴──────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "set cell render failed")
+}
+
+func TestHeaderVertical(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeaderVertical(true)
+	table.SetHeader([]string{"Jan", "Feb 漢"})
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want := `┌───┬────┐
+│ J │ F  │
+│ A │ E  │
+│ N │ B  │
+│   │    │
+│   │ 漢 │
+├───┼────┤
+│ 1 │  2 │
+└───┴────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "vertical header failed")
+}
+
+func TestHeaderTransform(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeaderBold(false)
+	table.SetHeaderTransform(func(col int, raw string) string {
+		if col == 0 {
+			return raw
+		}
+		return "[" + strings.ToLower(raw) + "]"
+	})
+	table.SetHeader([]string{"first_name", "AGE"})
+	table.Append([]string{"Bob", "42"})
+	table.Render()
+
+	want := "\x1b[2m┌────────────┬───────┐\n" +
+		"\x1b[2m│\x1b[0m first_name \x1b[2m│\x1b[0m [age] \x1b[2m│\x1b[0m\n" +
+		"\x1b[2m├────────────┼───────┤\n" +
+		"\x1b[2m│\x1b[0m Bob        \x1b[2m│\x1b[0m    42 \x1b[2m│\x1b[0m\n" +
+		"\x1b[2m└────────────┴───────┘\n"
+	checkEqual(t, buf.String(), want, "header transform failed")
+}
+
+func TestHeaderFollowColumnAlignment(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeaderFollowColumnAlignment(true)
+	table.SetColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_DEFAULT, ALIGN_CENTER})
+	table.SetHeader([]string{"Name", "Amount", "Code"})
+	table.AppendBulk([][]string{{"Apples", "5", "X"}, {"Pears", "1,200", "YYYYYY"}})
+	table.Render()
+
+	want := `┌────────┬────────┬────────┐
+│ NAME   │ AMOUNT │  CODE  │
+├────────┼────────┼────────┤
+│ Apples │      5 │   X    │
+│ Pears  │  1,200 │ YYYYYY │
+└────────┴────────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header follow column alignment failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetHeaderFollowColumnAlignment(true)
+	table.SetHeader([]string{"Name", "N"})
+	table.AppendBulk([][]string{{"Apples", "12345"}, {"Pears", ""}})
+	table.Render()
+
+	want = `┌────────┬───────┐
+│ NAME   │     N │
+├────────┼───────┤
+│ Apples │ 12345 │
+│ Pears  │       │
+└────────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header follow column alignment failed")
+}
+
+func TestAppendIf(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	checkEqual(t, table.AppendIf(false, []string{"A"}), false, "append if false failed")
+	checkEqual(t, table.AppendIf(true, []string{"B"}), true, "append if true failed")
+	checkEqual(t, table.NumLines(), 1, "append if lines failed")
+
+	rows := [][]string{{"1"}, {"22"}, {"3"}, {"44"}}
+	n := table.AppendBulkIf(rows, func(row []string) bool { return len(row[0]) == 2 })
+	checkEqual(t, n, 2, "append bulk if count failed")
+	checkEqual(t, table.NumLines(), 3, "append bulk if lines failed")
+	checkEqual(t, table.lines[2], [][]string{{"44"}}, "append bulk if rows failed")
+}
+
+func TestColumnFormatter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColumnFormatter(1, strings.TrimSpace)
+	table.SetColumnFormatter(1, strings.ToUpper)
+	table.SetColumnFormatter(1, func(s string) string { return "<" + s + ">" })
+	table.SetHeader([]string{"Name", "Code"})
+	table.AppendBulk([][]string{{" a ", "  xy  "}, {"b", "z"}})
+	table.Render()
+
+	want := `┌──────┬──────┐
+│ NAME │ CODE │
+├──────┼──────┤
+│  a   │ <XY> │
+│ b    │ <Z>  │
+└──────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column formatter failed")
+}
+
+func TestRenderTwice(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoMergeCells(merge)
+		table.SetHeader([]string{"Name", "Sign"})
+		table.AppendBulk([][]string{{"A", "The Very very Bad Man\nwith\nmany lines"}, {"B", "The Good"}})
+		lines := [][][]string{{{"A"}, {"The Very very Bad Man", "with many lines"}}, {{"B"}, {"The Good"}}}
+		checkEqual(t, table.lines, lines, "lines before render failed")
+
+		table.Render()
+		first := buf.String()
+		checkEqual(t, table.lines, lines, "render changed the lines")
+
+		buf.Reset()
+		table.Render()
+		checkEqual(t, buf.String(), first, "second render differs")
+	}
+}
+
+func TestPadNarrowColumns(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoMergeCells(merge)
+		table.SetReflowDuringAutoWrap(false)
+		table.SetParagraphGap(0)
+		table.AppendBulk([][]string{{"one\ntwo", "x"}, {"three", "y"}})
+		table.Render()
+
+		want := `┌───────┬───┐
+│ one   │ x │
+│ two   │   │
+│ three │ y │
+└───────┴───┘
+`
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "pad narrow columns failed")
+		checkEqual(t, table.lines[0], [][]string{{"one", "two"}, {"x"}}, "render changed the lines")
+	}
+}
+
+func TestComputeWidths(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeaderTransform(func(col int, raw string) string { return raw + "!!!" })
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+
+	checkEqual(t, table.ComputeWidths(), []int{7, 8}, "compute widths failed")
+	width := table.Width()
+	checkEqual(t, buf.Len(), 0, "compute widths wrote output")
+
+	table.Render()
+	line := strings.SplitN(buf.String(), "\n", 2)[0]
+	checkEqual(t, width, DisplayWidth(line), "width before render failed")
+}
+
+func TestAppendSpacer(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoMergeCells(merge)
+		table.SetHeader([]string{"Region", "Sales"})
+		table.AppendBulk([][]string{{"North", "10"}, {"North", "20"}})
+		table.AppendSpacer(2)
+		table.AppendSpacer(0)
+		table.Append([]string{"South", "30"})
+		table.Render()
+
+		want := `┌────────┬───────┐
+│ REGION │ SALES │
+├────────┼───────┤
+│ North  │    10 │
+│ North  │    20 │
+│        │       │
+│        │       │
+│ South  │    30 │
+└────────┴───────┘
+`
+		if merge {
+			want = strings.Replace(want, "│ North  │    20 │", "│        │    20 │", 1)
+		}
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "spacer failed")
+		checkEqual(t, table.NumLines(), 4, "spacer lines failed")
+	}
+}
+
+func TestRichMultiLine(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorder(false)
+	table.SetReflowDuringAutoWrap(false)
+	table.SetParagraphGap(0)
+	table.Rich([]string{"one\ntwo", "x"}, []Colors{{BgRedColor}, {}})
+	table.Render()
+
+	want := "  \x1b[41mone\x1b[0m \x1b[2m│\x1b[0m x  \n" +
+		"  \x1b[41mtwo\x1b[0m \x1b[2m│\x1b[0m    \n"
+	checkEqual(t, buf.String(), want, "rich multi-line failed")
+
+	buf.Reset()
+	table.SetRichPadding(true)
+	table.Render()
+
+	want = " \x1b[41m one \x1b[0m\x1b[2m│\x1b[0m x  \n" +
+		" \x1b[41m two \x1b[0m\x1b[2m│\x1b[0m    \n"
+	checkEqual(t, buf.String(), want, "rich padding failed")
+}
+
+func TestTabWidth(t *testing.T) {
+	checkEqual(t, expandTabs("a\tbc\td\n\tx", 4), "a   bc  d\n    x", "expand tabs failed")
+	checkEqual(t, expandTabs("漢\tx", 4), "漢  x", "expand wide tabs failed")
+	checkEqual(t, expandTabs("abcd\tx", 4), "abcd    x", "expand full tabs failed")
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetTabWidth(4)
+	table.SetAutoWrapText(false)
+	table.Append([]string{"a\tb\nccccc\td"})
+	table.Render()
+
+	want := `┌───────────┐
+│ a   b     │
+│ ccccc   d │
+└───────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "tab width failed")
+}
+
+func TestRenderAligned(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeaderAlignment(ALIGN_LEFT)
+	table.SetRowLine(true)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.AppendBulk([][]string{{"A", "The Good", "500"}, {"B", "The Ugly", "12"}})
+	extra := &bytes.Buffer{}
+	table.AddOutput(extra, FormatText)
+	if err := table.RenderAligned(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Name Sign     Rating\n" +
+		"A    The Good    500\n" +
+		"B    The Ugly     12\n"
+	checkEqual(t, buf.String(), want, "render aligned failed")
+	checkEqual(t, table.rowLine, true, "render aligned changed the settings")
+	checkEqual(t, table.autoFmt, true, "render aligned changed the settings")
+	checkEqual(t, table.noTrailingSpace, false, "render aligned changed the settings")
+	checkEqual(t, extra.Len(), 0, "render aligned wrote to the outputs")
+
+	table = NewWriter(&failingWriter{})
+	table.Append([]string{"A"})
+	if err := table.RenderAligned(); err == nil {
+		t.Error("render aligned to a failing writer should fail")
+	}
+	checkEqual(t, table.borders, Border{Left: true, Right: true, Top: true, Bottom: true}, "render aligned changed the settings")
+}
+
+func TestLongHeader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColWidth(12)
+	table.SetHeader([]string{"Total revenue for the quarter", "Region"})
+	table.Append([]string{"1,000", "North"})
+	table.Render()
+
+	want := `┌──────────────┬────────┐
+│    TOTAL     │ REGION │
+│ REVENUE FOR  │        │
+│ THE QUARTER  │        │
+├──────────────┼────────┤
+│        1,000 │ North  │
+└──────────────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "long header failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColWidth(12)
+	table.SetHeaderColWidth(20)
+	table.SetHeader([]string{"Total revenue for the quarter", "Region"})
+	table.Append([]string{"1,000", "North"})
+	table.Render()
+
+	want = `┌──────────────────────┬────────┐
+│  TOTAL REVENUE FOR   │ REGION │
+│     THE QUARTER      │        │
+├──────────────────────┼────────┤
+│                1,000 │ North  │
+└──────────────────────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header col width failed")
+}
+
+func TestColumnOrder(t *testing.T) {
+	type item struct {
+		Name  string
+		Price int
+		Stock int `tablewriter:"In stock"`
+	}
+	items := []item{{"Apple", 3, 10}, {"Pear", 4, 0}}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetAutoFormatHeaders(false)
+	table.SetColumnOrder([]string{"In stock", "Name"})
+	if err := table.SetStructs(items); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `┌──────────┬───────┬───────┐
+│ In stock │ Name  │ Price │
+├──────────┼───────┼───────┤
+│       10 │ Apple │     3 │
+│        0 │ Pear  │     4 │
+└──────────┴───────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column order failed")
+
+	table = NewWriter(&bytes.Buffer{})
+	table.SetColumnOrder([]string{"Stock"})
+	if err := table.SetStructs(items); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
+
+func TestCellWidth(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	checkEqual(t, table.CellWidth("abc"), 3, "plain width failed")
+	checkEqual(t, table.CellWidth("\x1b[31mabc\x1b[0m"), 3, "ansi width failed")
+	checkEqual(t, table.CellWidth("漢字"), 4, "wide width failed")
+	checkEqual(t, table.CellWidth("ab\nabcde\nc"), 5, "multi-line width failed")
+	checkEqual(t, table.CellWidth("a\tb"), 2, "tab width failed")
+
+	table.SetTabWidth(8)
+	checkEqual(t, table.CellWidth("a\tb"), 9, "expanded tab width failed")
+}
+
+func TestGroupSeparatorColumn(t *testing.T) {
+	data := [][]string{
+		{"North", "Jan", "10"},
+		{"North", "Feb", "20"},
+		{"South", "Jan", "30"},
+		{"East", "Jan", "40"},
+		{"East", "Feb", "50"},
+	}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetGroupSeparatorColumn(0)
+	table.SetHeader([]string{"Region", "Month", "Sales"})
+	table.AppendBulk(data)
+	table.Render()
+
+	want := `┌────────┬───────┬───────┐
+│ REGION │ MONTH │ SALES │
+├────────┼───────┼───────┤
+│ North  │ Jan   │    10 │
+│ North  │ Feb   │    20 │
+├────────┼───────┼───────┤
+│ South  │ Jan   │    30 │
+├────────┼───────┼───────┤
+│ East   │ Jan   │    40 │
+│ East   │ Feb   │    50 │
+└────────┴───────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "group separator failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetGroupSeparatorColumn(0)
+	table.SetAutoMergeCells(true)
+	table.SetHeader([]string{"Region", "Month", "Sales"})
+	table.AppendBulk(data)
+	table.Render()
+
+	want = `┌────────┬───────┬───────┐
+│ REGION │ MONTH │ SALES │
+├────────┼───────┼───────┤
+│ North  │ Jan   │    10 │
+│        │ Feb   │    20 │
+├────────┼───────┼───────┤
+│ South  │ Jan   │    30 │
+├────────┼───────┼───────┤
+│ East   │ Jan   │    40 │
+│        │ Feb   │    50 │
+└────────┴───────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "group separator merge cells failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetGroupSeparatorColumn(0)
+	table.SetRowLine(true)
+	table.AppendBulk(data[:2])
+	table.Render()
+
+	want = `┌───────┬─────┬────┐
+│ North │ Jan │ 10 │
+├───────┼─────┼────┤
+│ North │ Feb │ 20 │
+└───────┴─────┴────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "group separator with row line failed")
+}
+
+func TestHeaderAttribute(t *testing.T) {
+	tests := []struct {
+		attr Colors
+		want string
+	}{
+		{Colors{Bold}, "\x1b[1mNAME\x1b[0m"},
+		{Colors{UnderlineSingle, FgRedColor}, "\x1b[4;31mNAME\x1b[0m"},
+		{nil, " NAME "},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetHeaderAttribute(tt.attr)
+		table.SetHeader([]string{"Name"})
+		table.Render()
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("header attribute %v: %q does not contain %q", tt.attr, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestAppendNamed(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.AddColumn("Name")
+	table.AddColumn("Sign")
+	table.AddColumn("Rating")
+	if err := table.AddColumn("Sign"); err == nil {
+		t.Error("expected error for a duplicate column")
+	} else {
+		checkEqual(t, err.Error(), `duplicate column "Sign"`, "duplicate column error failed")
+	}
+
+	checkEqual(t, table.AppendNamed(map[string]string{"Rating": "500", "Name": "A", "Sign": "The Good"}), nil, "append named failed")
+	checkEqual(t, table.AppendNamed(map[string]string{"Name": "B"}), nil, "append named missing failed")
+	if err := table.AppendNamed(map[string]string{"Name": "C", "Score": "1", "Age": "2"}); err == nil {
+		t.Error("expected error for unknown columns")
+	} else {
+		checkEqual(t, err.Error(), `unknown columns ["Age" "Score"]`, "unknown columns error failed")
+	}
+	table.SetIgnoreUnknownColumns(true)
+	checkEqual(t, table.AppendNamed(map[string]string{"Name": "D", "Score": "1"}), nil, "append named ignore failed")
+	table.Render()
+
+	want := `┌──────┬──────────┬────────┐
+│ NAME │   SIGN   │ RATING │
+├──────┼──────────┼────────┤
+│ A    │ The Good │    500 │
+│ B    │          │        │
+│ D    │          │        │
+└──────┴──────────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "append named render failed")
+}
+
+func TestOutputFilter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	extra := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.AddOutput(extra, FormatText)
+	table.SetBorder(false)
+	table.SetOutputFilter(func(full string) string {
+		return "```\n" + ansi.ReplaceAllString(full, "") + "```\n"
+	})
+	calls := 0
+	table.SetRowRenderedHook(func(int) {
+		calls++
+		checkEqual(t, buf.Len(), 0, "output written before the filter")
+	})
+	table.Append([]string{"A", "500"})
+	table.Render()
+
+	want := "```\n" +
+		"  A │ 500  \n" +
+		"```\n"
+	checkEqual(t, buf.String(), want, "output filter failed")
+	checkEqual(t, extra.String(), want, "output filter for outputs failed")
+	checkEqual(t, calls, 1, "row hook with output filter failed")
+}
+
+func TestHeaderAtBottom(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoMergeCells(merge)
+		table.SetHeaderAtBottom(true)
+		table.SetHeader([]string{"Name", "Rating"})
+		table.AppendBulk([][]string{{"A", "500"}, {"B", "288"}})
+		table.Render()
+
+		want := `┌──────┬────────┐
+│ NAME │ RATING │
+├──────┼────────┤
+│ A    │    500 │
+│ B    │    288 │
+├──────┼────────┤
+│ NAME │ RATING │
+└──────┴────────┘
+`
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header at bottom failed")
+
+		buf.Reset()
+		table.SetRowLine(true)
+		table.Render()
+
+		want = `┌──────┬────────┐
+│ NAME │ RATING │
+├──────┼────────┤
+│ A    │    500 │
+├──────┼────────┤
+│ B    │    288 │
+├──────┼────────┤
+│ NAME │ RATING │
+└──────┴────────┘
+`
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header at bottom with row line failed")
+	}
+}
+
+func TestPadShortRows(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoMergeCells(merge)
+		table.SetPadShortRows(true)
+		table.SetHeader([]string{"A", "B"})
+		table.AppendBulk([][]string{{"1"}, {"1", "2", "3"}, {"x", "y"}})
+		table.Render()
+
+		want := `┌───┬───┬───┐
+│ A │ B │   │
+├───┼───┼───┤
+│ 1 │   │   │
+│ 1 │ 2 │ 3 │
+│ x │ y │   │
+└───┴───┴───┘
+`
+		if merge {
+			want = strings.Replace(want, "│ 1 │ 2 │ 3 │", "│   │ 2 │ 3 │", 1)
+		}
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "pad short rows failed")
+		checkEqual(t, len(table.lines[0]), 1, "pad short rows changed the lines")
+	}
+}
+
+func TestNewStdoutWriter(t *testing.T) {
+	checkEqual(t, NewStdoutWriter().out, io.Writer(os.Stdout), "stdout writer failed")
+	checkEqual(t, NewStderrWriter().out, io.Writer(os.Stderr), "stderr writer failed")
+}
+
+func TestColumnOverflow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColWidth(10)
+	table.SetColumnOverflow(0, OverflowTruncate)
+	table.SetHeader([]string{"ID", "Description"})
+	table.Append([]string{"0123456789abcdef", "a description that wraps"})
+	table.Append([]string{"short", "fits"})
+	table.Render()
+
+	want := `┌────────────┬─────────────┐
+│     ID     │ DESCRIPTION │
+├────────────┼─────────────┤
+│ 012345678… │ a           │
+│            │ description │
+│            │ that wraps  │
+│ short      │ fits        │
+└────────────┴─────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column overflow failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColWidth(10)
+	table.SetAutoWrapText(false)
+	table.SetColumnOverflow(1, OverflowWrap)
+	table.Append([]string{"0123456789abcdef", "a description that wraps"})
+	table.Render()
+
+	want = `┌──────────────────┬─────────────┐
+│ 0123456789abcdef │ a           │
+│                  │ description │
+│                  │ that wraps  │
+└──────────────────┴─────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column overflow wrap failed")
+}
+
+func TestColorLegend(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColorLegend([]LegendEntry{
+		{Color: Colors{FgRedColor}, Label: "error"},
+		{Color: Colors{FgYellowColor}, Label: "warning"},
+	})
+	table.Append([]string{"a"})
+	table.Render()
+
+	want := `┌───┐
+│ a │
+└───┘
+■ error  ■ warning
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "color legend failed")
+	if !strings.Contains(buf.String(), "\x1b[31m■\x1b[0m error") {
+		t.Errorf("color legend swatch is not colored: %q", buf.String())
+	}
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColorLegend(nil)
+	table.Append([]string{"a"})
+	table.Render()
+	checkEqual(t, strings.Count(buf.String(), "\n"), 3, "empty color legend failed")
+}
+
+func TestColWidthSmallerThanWideRune(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColWidth(1)
+	table.SetHeader([]string{"名", "x"})
+	table.Append([]string{"日 本語", "ab"})
+	table.Render()
+
+	want := `┌──────┬────┐
+│  名  │ X  │
+├──────┼────┤
+│ 日   │ ab │
+│ 本語 │    │
+└──────┴────┘
+`
+	got := ansi.ReplaceAllString(buf.String(), "")
+	checkEqual(t, got, want, "wide rune in narrow column failed")
+	if !utf8.ValidString(got) {
+		t.Errorf("wide rune in narrow column produced invalid UTF-8: %q", got)
+	}
+}
+
+func TestRenderFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Qty", "Note"})
+	table.Append([]string{"apple", "3", "red"})
+	if err := table.RenderFormat("%-8s %5s %v"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `┌──────────┬───────┬──────┐
+│   NAME   │  QTY  │ NOTE │
+├──────────┼───────┼──────┤
+│ apple    │     3 │  red │
+└──────────┴───────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "render format failed")
+
+	buf.Reset()
+	table.Render()
+	want = `┌───────┬─────┬──────┐
+│ NAME  │ QTY │ NOTE │
+├───────┼─────┼──────┤
+│ apple │   3 │ red  │
+└───────┴─────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "render after render format failed")
+
+	for _, spec := range []string{"%-8s %5s", "%-8s %5d %v", "%-8s, %5s %v"} {
+		if err := table.RenderFormat(spec); err == nil {
+			t.Errorf("render format %q should fail", spec)
+		}
+	}
+}
+
+func TestRenderFormatFloor(t *testing.T) {
+	for name, setup := range map[string]func(*Table){
+		"auto fit":   func(table *Table) { table.SetAutoFitToWidth(12) },
+		"percentile": func(table *Table) { table.SetColWidthPercentile(1, 50) },
+	} {
+		buf := &bytes.Buffer{}
+		extra := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetBorderStyle(ASCIIBorderStyle)
+		table.AddOutput(extra, FormatText)
+		setup(table)
+		table.Append([]string{"a", "one two"})
+		table.Append([]string{"b", "x"})
+		if err := table.RenderFormat("%-3s %-10s"); err != nil {
+			t.Fatal(err)
+		}
+
+		want := `+-----+------------+
+| a   | one two    |
+| b   | x          |
++-----+------------+
+`
+		checkEqual(t, buf.String(), want, name+": render format width failed")
+		checkEqual(t, extra.Len(), 0, name+": render format wrote to the outputs")
+	}
+
+	table := NewWriter(&failingWriter{})
+	table.Append([]string{"a"})
+	if err := table.RenderFormat("%s"); err == nil {
+		t.Error("render format to a failing writer should fail")
+	}
+}
+
+func TestBorderMode(t *testing.T) {
+	tests := []struct {
+		mode BorderMode
+		want string
+	}{
+		{BordersAll, `┌───┬───┐
+│ A │ B │
+├───┼───┤
+│ 1 │ 2 │
+├───┼───┤
+│ 3 │ 4 │
+└───┴───┘
+`},
+		{BordersNone, `  A   B  
+  1   2  
+  3   4  
+`},
+		{BordersOuter, `┌───────┐
+│ A   B │
+│ 1   2 │
+│ 3   4 │
+└───────┘
+`},
+		{BordersHorizontal, `─────────
+  A   B  
+─────────
+  1   2  
+─────────
+  3   4  
+─────────
+`},
+		{BordersVertical, `│ A │ B │
+│ 1 │ 2 │
+│ 3 │ 4 │
+`},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetBorderMode(tt.mode)
+		table.SetHeader([]string{"A", "B"})
+		table.AppendBulk([][]string{{"1", "2"}, {"3", "4"}})
+		table.Render()
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), tt.want, fmt.Sprintf("border mode %d failed", tt.mode))
+	}
+}
+
+type failingWriter struct {
+	n int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if f.n == 0 {
+		return 0, errors.New("write failed")
+	}
+	f.n--
+	return len(p), nil
+}
+
+func TestRenderTo(t *testing.T) {
+	out := &bytes.Buffer{}
+	table := NewWriter(out)
+	table.Append([]string{"a"})
+
+	buf := &bytes.Buffer{}
+	if err := table.RenderTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `┌───┐
+│ a │
+└───┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "render to failed")
+	checkEqual(t, out.Len(), 0, "render to wrote to the table writer")
+
+	table.Render()
+	checkEqual(t, out.String(), buf.String(), "render after render to failed")
+
+	w := &failingWriter{n: 2}
+	if err := table.RenderTo(w); err == nil || err.Error() != "write failed" {
+		t.Errorf("render to should fail, got %v", err)
+	}
+}
+
+func TestColWidthPercentile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColWidthPercentile(1, 75)
+	table.SetHeader([]string{"ID", "Value"})
+	table.AppendBulk([][]string{
+		{"1", "short"},
+		{"2", "tiny"},
+		{"3", "small"},
+		{"4", "a very long outlier value"},
+	})
+	table.Render()
+
+	want := `┌────┬─────────┐
+│ ID │  VALUE  │
+├────┼─────────┤
+│  1 │ short   │
+│  2 │ tiny    │
+│  3 │ small   │
+│  4 │ a very  │
+│    │ long    │
+│    │ outlier │
+│    │ value   │
+└────┴─────────┘
+`
+	got := ansi.ReplaceAllString(buf.String(), "")
+	checkEqual(t, got, want, "column width percentile failed")
+
+	buf.Reset()
+	table.Render()
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column width percentile render twice failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColWidthPercentile(0, 50)
+	table.SetColumnOverflow(0, OverflowTruncate)
+	table.AppendBulk([][]string{{"abc"}, {"abcd"}, {"abcdefgh"}})
+	table.Render()
+
+	want = `┌──────┐
+│ abc  │
+│ abcd │
+│ abc… │
+└──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column width percentile truncate failed")
+}
+
+func TestSummaryRow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Team", "Score", "Note"})
+	table.SetSummaryRow(SummaryCount, SummaryUniques, SummarySum, SummarySum)
+	table.AppendBulk([][]string{
+		{"ann", "red", "1,000", "x"},
+		{"bob", "red", "2.5", ""},
+		{"", "blue", "3", "y"},
+	})
+	table.Render()
+
+	want := `┌──────┬──────┬────────┬──────┐
+│ NAME │ TEAM │ SCORE  │ NOTE │
+├──────┼──────┼────────┼──────┤
+│ ann  │ red  │  1,000 │ x    │
+│ bob  │ red  │    2.5 │      │
+│      │ blue │      3 │ y    │
+├──────┼──────┼────────┼──────┤
+│    2 │    2 │ 1005.5 │      │
+└──────┴──────┴────────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "summary row failed")
+
+	buf.Reset()
+	table.Render()
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "summary row render twice failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetRowLine(true)
+	table.SetSummaryRow(SummarySum)
+	table.AppendBulk([][]string{{"1", "a"}, {"2", "b"}})
+	table.Render()
+
+	want = `┌───┬───┐
+│ 1 │ a │
+├───┼───┤
+│ 2 │ b │
+├───┼───┤
+│ 3 │   │
+└───┴───┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "summary row with row lines failed")
+}
+
+func TestHeaderAlignmentDiffersFromData(t *testing.T) {
+	tests := []struct {
+		header, data int
+		want         string
+	}{
+		{ALIGN_RIGHT, ALIGN_LEFT, `┌─────────────────┬────────┐
+│     NAME LONGER │      V │
+│            LINE │        │
+├─────────────────┼────────┤
+│ a               │ 123456 │
+│ bbbbbbbbbbbbbbb │ 1      │
+└─────────────────┴────────┘
+`},
+		{ALIGN_LEFT, ALIGN_RIGHT, `┌─────────────────┬────────┐
+│ NAME LONGER     │ V      │
+│ LINE            │        │
+├─────────────────┼────────┤
+│               a │ 123456 │
+│ bbbbbbbbbbbbbbb │      1 │
+└─────────────────┴────────┘
+`},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetHeaderAlignment(tt.header)
+		table.SetAlignment(tt.data)
+		table.SetHeader([]string{"name\nlonger line", "v"})
+		table.AppendBulk([][]string{{"a", "123456"}, {"bbbbbbbbbbbbbbb", "1"}})
+		table.Render()
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), tt.want, "header alignment differing from data failed")
+	}
+
+	// The colored header is as wide as the data without white space
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorderMode(BordersNone)
+	table.SetNoWhiteSpace(true)
+	table.SetTablePadding("|")
+	table.SetHeaderAlignment(ALIGN_RIGHT)
+	table.SetAlignment(ALIGN_LEFT)
+	table.SetHeader([]string{"a", "b"})
+	table.SetHeaderColor(Colors{Bold}, Colors{Bold})
+	table.Append([]string{"xyz", "w"})
+	table.Render()
+
+	want := `  A|B|
+xyz|w|
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "colored header without white space failed")
+}
+
+func TestEscapeBorderChars(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetEscapeBorderChars(true)
+	table.SetHeader([]string{"a│b"})
+	table.Append([]string{"┌─┐\n└─┘"})
+	table.Append([]string{"\x1b[31mred\x1b[0m"})
+	table.Render()
+
+	want := `┌─────┐
+│ A|B │
+├─────┤
+│ +-+ │
+│ +-+ │
+│ red │
+└─────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "escape border chars failed")
+	if !strings.Contains(buf.String(), "\x1b[31mred\x1b[0m") {
+		t.Errorf("escape border chars changed an escape sequence: %q", buf.String())
+	}
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.Append([]string{"x│y"})
+	table.SetEscapeBorderChars(true)
+	table.Render()
+	want = `┌─────┐
+│ x|y │
+└─────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "escape border chars after append failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetEscapeBorderChars(true)
+	table.Append([]string{"x│y", "p|q", "-1+2"})
+	table.SetBorderStyle(ASCIIBorderStyle)
+	table.Render()
+	want = `+-----+-----+------+
+| x│y | p¦q | -1+2 |
++-----+-----+------+
+`
+	checkEqual(t, buf.String(), want, "escape ASCII border chars failed")
+}
+
+func TestStructTagAlign(t *testing.T) {
+	type item struct {
+		Name   string `tablewriter:"Name,align=right"`
+		Amount int    `tablewriter:",align=left"`
+		Note   string
+	}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	if err := table.SetStructs([]item{{"a", 10, "x"}, {"bcd", 2, "y"}}); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `┌──────┬────────┬──────┐
+│ NAME │ AMOUNT │ NOTE │
+├──────┼────────┼──────┤
+│    a │ 10     │ x    │
+│  bcd │ 2      │ y    │
+└──────┴────────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "struct tag align failed")
+
+	type invalid struct {
+		Name string `tablewriter:"Name,align=middle"`
+	}
+	err := NewWriter(&bytes.Buffer{}).SetStructs([]invalid{{"a"}})
+	if err == nil || err.Error() != `field Name: invalid align "middle"` {
+		t.Errorf("invalid struct tag align should fail, got %v", err)
+	}
+}
+
+func TestCenterBias(t *testing.T) {
+	checkEqual(t, PadBias("ab", " ", 5, BiasRight), " ab  ")
+	checkEqual(t, PadBias("ab", " ", 5, BiasLeft), "  ab ")
+	checkEqual(t, Pad("ab", " ", 5), PadBias("ab", " ", 5, BiasRight))
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetCenterBias(BiasLeft)
+	table.SetAlignment(ALIGN_CENTER)
+	table.SetHeader([]string{"ab"})
+	table.AppendBulk([][]string{{"abcde"}, {"x"}})
+	table.Render()
+
+	want := `┌───────┐
+│   AB  │
+├───────┤
+│ abcde │
+│   x   │
+└───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "center bias failed")
+}
+
+func TestHeaderSpans(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeaderSpans([]int{1, 2})
+	table.SetHeader([]string{"Name", "Dimensions in centimetres"})
+	table.AppendBulk([][]string{{"box", "10", "20"}, {"tube", "5", "100"}})
+	table.Render()
+
+	want := `┌──────┬───────────────────────────┐
+│ NAME │ DIMENSIONS IN CENTIMETRES │
+├──────┼────┬──────────────────────┤
+│ box  │ 10 │                   20 │
+│ tube │  5 │                  100 │
+└──────┴────┴──────────────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header spans failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetHeaderSpans([]int{2})
+	table.SetHeaderAtBottom(true)
+	table.SetHeader([]string{"Size"})
+	table.AppendBulk([][]string{{"10", "20", "box"}, {"5", "100", "tube"}})
+	table.Render()
+
+	want = `┌──────────┬──────┐
+│   SIZE   │      │
+├────┬─────┼──────┤
+│ 10 │  20 │ box  │
+│  5 │ 100 │ tube │
+├────┴─────┼──────┤
+│   SIZE   │      │
+└──────────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header spans at bottom failed")
+}
+
+func TestCellHeight(t *testing.T) {
+	tests := []struct {
+		text   string
+		reflow bool
+		gap    int
+		want   int
+	}{
+		{"", true, 1, 1},
+		{"one two three four", true, 1, 2},
+		{"a\nb", true, 1, 2},
+		{"ab\nc d", true, 1, 2},
+		{"a\nb", false, 1, 3},
+		{"a\nb", false, 0, 2},
+		{"a\n\nb", false, 1, 3},
+		{"a\n\nb", false, 2, 3},
+		{"a\nb", false, 2, 4},
+		{"a\n", false, 1, 2},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetColWidth(10)
+		table.SetReflowDuringAutoWrap(tt.reflow)
+		table.SetParagraphGap(tt.gap)
+		checkEqual(t, table.CellHeight(tt.text), tt.want, fmt.Sprintf("cell height of %q failed", tt.text))
+
+		table.SetBorderMode(BordersNone)
+		table.Append([]string{tt.text})
+		table.Render()
+		checkEqual(t, strings.Count(buf.String(), "\n"), tt.want, fmt.Sprintf("rendered height of %q failed", tt.text))
+	}
+}
+
+func TestRenderKV(t *testing.T) {
+	type address struct {
+		City string
+		Zip  string `tablewriter:"Postcode"`
+	}
+	type person struct {
+		Name     string
+		Age      int `tablewriter:"Years,align=right"`
+		Home     address
+		Work     *address
+		Password string `tablewriter:"-"`
+		private  string
+	}
+	p := &person{Name: "Ann", Age: 30, Home: address{"Paris", "75001"}, Password: "secret", private: "x"}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	if err := table.RenderKV(p); err != nil {
+		t.Fatal(err)
+	}
+	want := `┌───────┬───────────────┐
+│ Name  │ Ann           │
+│ Years │            30 │
+│ Home  │ {Paris 75001} │
+│ Work  │ nil           │
+└───────┴───────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "render kv failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetKVFlatten(true)
+	table.SetColumnAlignment([]int{ALIGN_RIGHT, ALIGN_LEFT})
+	if err := table.RenderKV(*p); err != nil {
+		t.Fatal(err)
+	}
+	want = `┌───────────────┬───────┐
+│          Name │ Ann   │
+│         Years │ 30    │
+│     Home.City │ Paris │
+│ Home.Postcode │ 75001 │
+│          Work │ nil   │
+└───────────────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "render kv flatten failed")
+
+	if err := NewWriter(buf).RenderKV([]person{}); err == nil {
+		t.Error("render kv of a slice should fail")
+	}
+}
+
+func TestHeaderStyle(t *testing.T) {
+	for _, tt := range []struct {
+		header, group bool
+		want          string
+	}{
+		{true, false, `┌────────────┬──────┐
+│ size in cm │ NAME │
+├────┬───────┼──────┤
+│ 10 │    20 │ box  │
+└────┴───────┴──────┘
+`},
+		{false, true, `┌────────────┬──────┐
+│ SIZE IN CM │ name │
+├────┬───────┼──────┤
+│ 10 │    20 │ box  │
+└────┴───────┴──────┘
+`},
+	} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetHeaderStyle(tt.header)
+		table.SetGroupHeaderStyle(tt.group)
+		table.SetHeaderSpans([]int{2, 1})
+		table.SetHeader([]string{"size in cm", "name"})
+		table.Append([]string{"10", "20", "box"})
+		table.Render()
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), tt.want, "header style failed")
+	}
+}
+
+func TestClearEOL(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoMergeCells(merge)
+		table.SetClearEOL(true)
+		table.SetHeader([]string{"a", "b"})
+		table.AppendBulk([][]string{{"1", "2"}, {"1", "3"}})
+		table.Render()
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		checkEqual(t, len(lines), 6, "clear eol changed the lines")
+		for _, line := range lines {
+			if !strings.HasSuffix(line, "│\x1b[0m"+CLEAR_EOL) && !strings.HasSuffix(line, "┐"+CLEAR_EOL) &&
+				!strings.HasSuffix(line, "┤"+CLEAR_EOL) && !strings.HasSuffix(line, "┘"+CLEAR_EOL) {
+				t.Errorf("line does not end with the border and clear eol: %q", line)
+			}
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.Append([]string{"a"})
+	table.Render()
+	if strings.Contains(buf.String(), CLEAR_EOL) {
+		t.Errorf("clear eol is written when disabled: %q", buf.String())
+	}
+}
+
+func TestColumnAlignmentMap(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetAlignment(ALIGN_LEFT)
+	table.SetColumnAlignmentMap(map[int]int{1: ALIGN_RIGHT, 3: ALIGN_CENTER})
+	table.SetHeader([]string{"a", "b", "c", "d"})
+	table.AppendBulk([][]string{{"x", "y", "z", "w"}, {"xxx", "yyy", "zzz", "www"}})
+	table.Render()
+
+	want := `┌─────┬─────┬─────┬─────┐
+│  A  │  B  │  C  │  D  │
+├─────┼─────┼─────┼─────┤
+│ x   │   y │ z   │  w  │
+│ xxx │ yyy │ zzz │ www │
+└─────┴─────┴─────┴─────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column alignment map failed")
+}
+
+func TestColumnBool(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColumnBool(1, "✓", "✗")
+	table.SetColumnBool(2, "yes", "no")
+	table.SetBoolValues([]string{"true", "on"}, []string{"false", "off"})
+	table.SetHeader([]string{"Name", "Enabled", "Ready"})
+	table.AppendBulk([][]string{
+		{"a", "TRUE", "on"},
+		{"b", "false", "Off"},
+		{"c", "unknown", "true"},
+	})
+	table.Render()
+
+	want := `┌──────┬─────────┬───────┐
+│ NAME │ ENABLED │ READY │
+├──────┼─────────┼───────┤
+│ a    │    ✓    │  yes  │
+│ b    │    ✗    │  no   │
+│ c    │ unknown │  yes  │
+└──────┴─────────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column bool failed")
+}
+
+func TestTrailingNewline(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetTrailingNewline(false)
+	table.SetNewLine("\r\n")
+	table.Append([]string{"a"})
+	table.Render()
+
+	want := "┌───┐\r\n│ a │\r\n└───┘"
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "trailing newline failed")
+
+	buf.Reset()
+	table.SetTrailingNewline(true)
+	table.Render()
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want+"\r\n", "trailing newline restored failed")
+}
+
+func TestRowHeight(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.AppendBulk([][]string{{"a", "b"}, {"c\nd\ne", "f"}})
+	if err := table.SetRowHeight(0, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.SetRowHeight(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `┌───┬───┐
+│ a │ b │
+│   │   │
+│   │   │
+│ c │ f │
+│ d │   │
+└───┴───┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "row height failed")
+
+	buf.Reset()
+	table.SetCell(0, 0, "g")
+	table.Render()
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), strings.Replace(want, "│ a │", "│ g │", 1), "row height after render failed")
+
+	if err := table.SetRowHeight(2, 1); err == nil {
+		t.Error("row height of an unknown row should fail")
+	}
+	if err := table.SetRowHeight(0, 0); err == nil {
+		t.Error("row height of zero lines should fail")
+	}
+}
+
+func TestCollapseBlankRows(t *testing.T) {
+	rows := [][]string{{"a", "b"}, {"", " "}, {"", ""}, {"c", "d"}, {"", ""}}
+	for _, tt := range []struct {
+		collapse, remove, merge bool
+		want                    string
+	}{
+		{true, false, false, `┌───┬───┐
+│ a │ b │
+│   │   │
+│ c │ d │
+│   │   │
+└───┴───┘
+`},
+		{false, true, false, `┌───┬───┐
+│ a │ b │
+│ c │ d │
+└───┴───┘
+`},
+		{true, false, true, `┌───┬───┐
+│ a │ b │
+│   │   │
+│ c │ d │
+│   │   │
+└───┴───┘
+`},
+	} {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetCollapseBlankRows(tt.collapse)
+		table.SetRemoveBlankRows(tt.remove)
+		table.SetAutoMergeCells(tt.merge)
+		table.AppendBulk(rows)
+		table.Render()
+		checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), tt.want, "collapse blank rows failed")
+	}
+
+	// Spacers are not blank rows
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetRemoveBlankRows(true)
+	table.Append([]string{"a"})
+	table.AppendSpacer(1)
+	table.Append([]string{"b"})
+	table.Render()
+	checkEqual(t, strings.Count(buf.String(), "\n"), 5, "remove blank rows removed a spacer")
+}
+
+func TestNumericCellSpaces(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"n", "s"})
+	table.AppendBulk([][]string{{" 42 ", " a "}, {"1.5", "b"}, {"7  ", "c"}})
+	table.Render()
+
+	want := `┌──────┬─────┐
+│  N   │  S  │
+├──────┼─────┤
+│   42 │  a  │
+│  1.5 │ b   │
+│    7 │ c   │
+└──────┴─────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "numeric cell with spaces failed")
+}
+
+func TestJunction(t *testing.T) {
+	glyphs := map[[4]bool]string{
+		{false, false, false, false}: " ",
+		{true, false, false, false}:  "│",
+		{false, true, false, false}:  "│",
+		{true, true, false, false}:   "│",
+		{false, false, true, false}:  "─",
+		{false, false, false, true}:  "─",
+		{false, false, true, true}:   "─",
+		{true, true, true, true}:     "┼",
+		{true, true, false, true}:    "├",
+		{true, true, true, false}:    "┤",
+		{false, true, true, true}:    "┬",
+		{true, false, true, true}:    "┴",
+		{false, true, false, true}:   "┌",
+		{true, false, false, true}:   "└",
+		{false, true, true, false}:   "┐",
+		{true, false, true, false}:   "┘",
+	}
+	for s, want := range glyphs {
+		got := ansi.ReplaceAllString(DefaultBorderStyle.junction(s[0], s[1], s[2], s[3]), "")
+		checkEqual(t, got, want, fmt.Sprintf("junction up=%v down=%v left=%v right=%v failed", s[0], s[1], s[2], s[3]))
+	}
+}
+
+func TestRenderPaged(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetContinuedMarker("(continued)")
+	table.SetHeader([]string{"n"})
+	table.AppendBulk([][]string{{"1"}, {"2\n2"}, {"3"}, {"4"}})
+	if err := table.RenderPaged(buf, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `┌───┐
+│ N │
+├───┤
+│ 1 │
+└───┘
+(continued)
+` + "\f" + `┌───┐
+│ N │
+├───┤
+│ 2 │
+│ 2 │
+└───┘
+(continued)
+` + "\f" + `┌───┐
+│ N │
+├───┤
+│ 3 │
+│ 4 │
+└───┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "render paged failed")
+	for _, page := range strings.Split(buf.String(), "\f") {
+		if n := strings.Count(page, "\n"); n > 7 {
+			t.Errorf("page of %d lines is taller than 7", n)
+		}
+	}
+
+	buf.Reset()
+	table.Render()
+	checkEqual(t, strings.Count(buf.String(), "\n"), 9, "render after render paged failed")
+
+	if err := table.RenderPaged(&bytes.Buffer{}, 5); err == nil {
+		t.Error("render paged with a row taller than a page should fail")
+	}
+
+	buf.Reset()
+	table.SetBOM(true)
+	table.SetOutputFilter(strings.ToLower)
+	if err := table.RenderPaged(buf, 7); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, strings.Count(buf.String(), "\ufeff"), 1, "render paged with a BOM failed")
+	checkEqual(t, strings.Count(ansi.ReplaceAllString(buf.String(), ""), "│ n │"), 3, "render paged with an output filter failed")
+	if table.pageRows != nil || table.morePages || !table.bom {
+		t.Error("render paged changed the table")
+	}
+}
+
+func TestColumnHumanize(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColumnHumanize(0, HumanizeSI)
+	table.SetColumnHumanize(1, HumanizeBytes)
+	table.SetHeader([]string{"count", "size"})
+	table.AppendBulk([][]string{
+		{"999", "512"},
+		{"1536", "1048576"},
+		{"1,048,576", "1536"},
+		{"999999", "n/a"},
+	})
+	table.Render()
+
+	want := `┌───────┬─────────┐
+│ COUNT │  SIZE   │
+├───────┼─────────┤
+│   999 │   512 B │
+│  1.5K │ 1.0 MiB │
+│  1.0M │ 1.5 KiB │
+│  1.0M │ n/a     │
+└───────┴─────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column humanize failed")
+	checkEqual(t, table.rows[1], []string{"1536", "1048576"}, "column humanize changed the original values")
+}
+
+func TestColumnAlignmentFor(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColumnAlignmentFor(SectionHeader, 0, ALIGN_RIGHT)
+	table.SetColumnAlignmentFor(SectionBody, 0, ALIGN_LEFT)
+	table.SetColumnAlignmentFor(SectionFooter, 0, ALIGN_CENTER)
+	table.SetColumnAlignmentFor(SectionBody, 1, ALIGN_RIGHT)
+	table.SetSummaryRow(SummaryCount, SummaryCount)
+	table.SetHeader([]string{"a", "b"})
+	table.AppendBulk([][]string{{"1", "x"}, {"22222", "yyyyy"}})
+	table.Render()
+
+	want := `┌───────┬───────┐
+│     A │   B   │
+├───────┼───────┤
+│ 1     │     x │
+│ 22222 │ yyyyy │
+├───────┼───────┤
+│   2   │     2 │
+└───────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column alignment for sections failed")
+}
+
+func TestGrid(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Qty"})
+	table.SetColWidth(5)
+	table.Append([]string{"apple pie", "3"})
+	table.Rich([]string{"pear", "12"}, []Colors{{}, {FgRedColor}})
+
+	g := table.Grid()
+	if g.Rows() != 2 || g.Columns() != 2 {
+		t.Fatalf("grid is %dx%d, want 2x2", g.Rows(), g.Columns())
+	}
+	checkEqual(t, g.ColumnWidths(), []int{5, 3}, "column widths")
+	checkEqual(t, g.RowHeights(), []int{2, 1}, "row heights")
+	checkEqual(t, g.Header(0).Lines, []string{"Name"}, "header lines")
+	checkEqual(t, g.Header(1).Align, ALIGN_CENTER, "header alignment")
+
+	cell := g.Cell(0, 0)
+	checkEqual(t, cell.Lines, []string{"apple", "pie"}, "wrapped lines")
+	checkEqual(t, cell.Align, ALIGN_LEFT, "text alignment")
+	checkEqual(t, g.Cell(1, 1).Align, ALIGN_RIGHT, "number alignment")
+	checkEqual(t, g.Cell(1, 1).Colors, Colors{FgRedColor}, "cell colors")
+	checkEqual(t, len(g.Cell(5, 0).Lines), 0, "cell out of range")
+
+	table.Append([]string{"plum", "1"})
+	checkEqual(t, g.Rows(), 2, "grid is a snapshot")
+}
+
+func TestColumnMask(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"Name", "Token"})
+	table.SetColumnMask(1, '•', 4)
+	table.AppendBulk([][]string{
+		{"ci", "sk-live-12345678"},
+		{"dev", "abc"},
+		{"empty", ""},
+	})
+	table.Render()
+
+	want := `┌───────┬──────────────────┐
+│ NAME  │      TOKEN       │
+├───────┼──────────────────┤
+│ ci    │ ••••••••••••5678 │
+│ dev   │ •••              │
+│ empty │                  │
+└───────┴──────────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column mask failed")
+	checkEqual(t, table.rows[0][1], "sk-live-12345678", "column mask changed the original value")
+}
+
+func TestCodeFence(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetCodeFence("```", "text")
+	table.SetHeader([]string{"a", "b"})
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want := "```text\n" + `┌───┬───┐
+│ A │ B │
+├───┼───┤
+│ 1 │ 2 │
+└───┴───┘
+` + "```\n"
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "code fence failed")
+
+	buf.Reset()
+	table.SetCodeFence("~~~", "")
+	table.SetTrailingNewline(false)
+	table.Render()
+	out := ansi.ReplaceAllString(buf.String(), "")
+	if !strings.HasPrefix(out, "~~~\n┌") || !strings.HasSuffix(out, "┘\n~~~") {
+		t.Errorf("code fence without language or trailing newline failed:\n%s", out)
+	}
+}
+
+func TestHideEmptyColumns(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHideEmptyColumns(true)
+	table.SetHeader([]string{"Name", "", "Size"})
+	table.SetColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_DEFAULT, ALIGN_CENTER})
+	table.AppendBulk([][]string{
+		{"alpha", "", "1"},
+		{"beta", "", "100"},
+	})
+	table.Render()
+
+	want := `┌───────┬──────┐
+│ NAME  │ SIZE │
+├───────┼──────┤
+│ alpha │  1   │
+│ beta  │ 100  │
+└───────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "hide empty columns failed")
+
+	buf.Reset()
+	table.SetHideEmptyColumns(false)
+	table.Render()
+	if !strings.Contains(ansi.ReplaceAllString(buf.String(), ""), "│ alpha │  │  1   │") {
+		t.Errorf("empty column hidden when not asked:\n%s", buf.String())
+	}
+}
+
+func TestAppendFromChan(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"n"})
+
+	ch := make(chan []string)
+	go func() {
+		for _, n := range []string{"1", "2", "3"} {
+			ch <- []string{n}
+		}
+		close(ch)
+	}()
+	checkEqual(t, table.AppendFromChan(ch), 3, "rows appended from channel")
+	checkEqual(t, table.NumLines(), 3, "rows of the table")
+}
+
+func TestTruncateAlignment(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColWidth(6)
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT, ALIGN_CENTER, ALIGN_DEFAULT})
+	for y := 0; y < 4; y++ {
+		table.SetColumnOverflow(y, OverflowTruncate)
+	}
+	table.Append([]string{"abcdefghij", "abcdefghij", "abcdefghij", "1234567890"})
+	table.Render()
+
+	want := `┌────────┬────────┬────────┬────────┐
+│ abcde… │ …fghij │ …defg… │ …67890 │
+└────────┴────────┴────────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "truncate by alignment failed")
+}
+
+func TestCaseLanguage(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetCaseLanguage(language.Turkish)
+	table.SetHeader([]string{"id", "şehir"})
+	table.Append([]string{"1", "İzmir"})
+	table.Render()
+
+	want := `┌────┬───────┐
+│ İD │ ŞEHİR │
+├────┼───────┤
+│  1 │ İzmir │
+└────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "case language failed")
+}
+
+func TestAutoWidth(t *testing.T) {
+	defer func(f func(io.Writer) (int, bool)) { terminalWidth = f }(terminalWidth)
+	columns := 30
+	terminalWidth = func(w io.Writer) (int, bool) { return columns, true }
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetAutoWidth(2)
+	table.SetColWidth(60)
+	table.SetHeader([]string{"Key", "Description"})
+	table.Append([]string{"a", "the quick brown fox jumps over the lazy dog"})
+	table.Render()
+
+	want := `┌─────┬────────────────────┐
+│ KEY │    DESCRIPTION     │
+├─────┼────────────────────┤
+│ a   │ the quick brown    │
+│     │ fox jumps over the │
+│     │ lazy dog           │
+└─────┴────────────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "auto width failed")
+
+	// The table follows the terminal when it grows again.
+	buf.Reset()
+	columns = 80
+	table.Render()
+	if !strings.Contains(buf.String(), "the quick brown fox jumps over the lazy dog") {
+		t.Errorf("auto width did not widen the table again:\n%s", buf.String())
+	}
+}
+
+func TestShrinkStrategy(t *testing.T) {
+	defer func(f func(io.Writer) (int, bool)) { terminalWidth = f }(terminalWidth)
+	columns := 0
+	terminalWidth = func(w io.Writer) (int, bool) { return columns, true }
+
+	widths := func(s ShrinkStrategy, width int) []int {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetAutoWidth(0)
+		table.SetShrinkStrategy(s)
+		table.SetColWidth(60)
+		table.SetColMinWidth(0, 6)
+		table.Append([]string{"id a b c d", "aa bb cc dd ee ff gg hh", "xx yy zz ww vv"})
+		columns = width
+		table.Render()
+
+		var got []int
+		line := strings.SplitN(ansi.ReplaceAllString(buf.String(), ""), "\n", 2)[0]
+		for _, segment := range strings.FieldsFunc(line, func(r rune) bool { return r != '─' }) {
+			got = append(got, utf8.RuneCountInString(segment)-2)
+		}
+		return got
+	}
+
+	// The columns are 10, 23 and 14 wide, 57 with the borders.
+	checkEqual(t, widths(ShrinkProportional, 57), []int{10, 23, 14}, "no shrink needed")
+	checkEqual(t, widths(ShrinkProportional, 47), []int{9, 17, 11}, "proportional")
+	checkEqual(t, widths(ShrinkWidestFirst, 47), []int{10, 13, 14}, "widest first")
+	checkEqual(t, widths(ShrinkWidestFirst, 30), []int{6, 7, 7}, "widest first to the minimum")
+	checkEqual(t, widths(ShrinkColumns(1), 47), []int{10, 13, 14}, "only column 1")
+	checkEqual(t, widths(ShrinkColumns(1, 0), 47), []int{10, 13, 14}, "column 1 first")
+	checkEqual(t, widths(ShrinkColumns(1, 0), 30), []int{6, 2, 14}, "column 1 then column 0")
+	checkEqual(t, widths(ShrinkColumns(2), 20), []int{10, 23, 2}, "only column 2, to its minimum")
+}
+
+func TestNormalizeWidth(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetNormalizeWidth(true)
+	table.SetAutoMergeCells(true)
+	table.SetRowLine(true)
+	table.AppendBulk([][]string{
+		{"\u200balpha\u200b", "1"},
+		{"alpha", "2"},
+	})
+	table.Render()
+
+	want := `┌───────┬───┐
+│ alpha │ 1 │
+│       ├───┤
+│       │ 2 │
+└───────┴───┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "normalize width failed")
+}
+
+func TestValueTransformer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"name", "price"})
+	table.SetValueTransformer(func(row, col int, value string) string {
+		if col == 0 {
+			return fmt.Sprintf("%d. %s", row+1, value)
+		}
+		return value + "0"
+	})
+	table.SetColumnFormatter(1, func(value string) string { return "$" + value })
+	table.AppendBulk([][]string{{"tea", "3.5"}, {"coffee", "4.2"}})
+	table.Render()
+
+	want := `┌───────────┬───────┐
+│   NAME    │ PRICE │
+├───────────┼───────┤
+│ 1. tea    │ $3.50 │
+│ 2. coffee │ $4.20 │
+└───────────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "value transformer failed")
+}
+
+func TestFooter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"item", "amount"})
+	table.SetFooter([]string{"Total\n(EUR)", ""})
+	table.SetSummaryRow(SummaryNone, SummarySum)
+	table.AppendBulk([][]string{{"rent", "1200"}, {"groceries", "350.5"}})
+	table.Render()
+
+	want := `┌───────────┬────────┐
+│   ITEM    │ AMOUNT │
+├───────────┼────────┤
+│ rent      │   1200 │
+│ groceries │  350.5 │
+├───────────┼────────┤
+│     Total │ 1550.5 │
+│     (EUR) │        │
+└───────────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "footer failed")
+
+	buf.Reset()
+	table.SetFooterAlignment(ALIGN_LEFT)
+	table.Render()
+	if !strings.Contains(ansi.ReplaceAllString(buf.String(), ""), "│ Total     │ 1550.5 │") {
+		t.Errorf("footer alignment failed:\n%s", buf.String())
+	}
+}
+
+func TestBorderStyle(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorderStyle(ASCIIBorderStyle)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeaderStyle(false)
+	table.SetHeader([]string{"a", "b"})
+	table.AppendBulk([][]string{{"1", "2"}, {"3", "4"}})
+	table.SetRowLine(true)
+	table.Render()
+
+	want := `+---+---+
+| a | b |
++---+---+
+| 1 | 2 |
++---+---+
+| 3 | 4 |
++---+---+
+`
+	checkEqual(t, buf.String(), want, "ASCII border style failed")
+}
+
+func TestRenderCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"name", "note", "key"})
+	table.SetColumnMask(2, '*', 2)
+	table.Rich([]string{"Smith, J", "says \"hi\"", "abcd"}, []Colors{{FgRedColor}})
+	table.AppendSpacer(1)
+	table.Append([]string{"\x1b[1mDoe\x1b[0m", "line 1\nline 2", "xy"})
+	if err := table.RenderCSV(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `name,note,key
+"Smith, J","says ""hi""",**cd
+Doe,"line 1
+line 2",**
+`
+	checkEqual(t, buf.String(), want, "CSV failed")
+
+	buf.Reset()
+	table.SetCSVSeparator('\t')
+	table.SetCSVUnmasked(true)
+	if err := table.RenderCSV(); err != nil {
+		t.Fatal(err)
+	}
+	want = "name\tnote\tkey\nSmith, J\t\"says \"\"hi\"\"\"\tabcd\nDoe\t\"line 1\nline 2\"\txy\n"
+	checkEqual(t, buf.String(), want, "TSV failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.AddColumn("name")
+	table.AddColumn("qty")
+	table.AppendNamed(map[string]string{"name": "apple", "qty": "3"})
+	if err := table.RenderCSV(); err != nil {
+		t.Fatal(err)
+	}
+	checkEqual(t, buf.String(), "name,qty\napple,3\n", "CSV with added columns failed")
+}
+
+func TestRichWrappedLines(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorder(false)
+	table.SetColWidth(10)
+	table.Rich([]string{"connection refused by peer"}, []Colors{{FgRedColor}})
+	table.Render()
+
+	want := "  \x1b[31mconnection\x1b[0m  \n" +
+		"  \x1b[31mrefused by\x1b[0m  \n" +
+		"  \x1b[31mpeer\x1b[0m        \n"
+	checkEqual(t, buf.String(), want, "rich wrapped lines failed")
+}
+
+func TestRenderErr(t *testing.T) {
+	w := &failingWriter{n: 2}
+	table := NewWriter(w)
+	table.AppendBulk([][]string{{"a"}, {"b"}})
+	if err := table.RenderErr(); err == nil || err.Error() != "write failed" {
+		t.Errorf("render should fail, got %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	table = NewWriter(buf)
+	table.Append([]string{"a"})
+	if err := table.RenderErr(); err != nil {
+		t.Errorf("render failed: %v", err)
+	}
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), "┌───┐\n│ a │\n└───┘\n", "render err output failed")
+}
+
+func TestAutoFitToWidth(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetAutoFitToWidth(24)
+	table.SetColWidth(60)
+	table.SetColMinWidth(0, 4)
+	table.SetHeader([]string{"id", "message"})
+	table.Append([]string{"7", "disk quota exceeded on volume data"})
+	table.Render()
+
+	want := `┌──────┬───────────────┐
+│  ID  │    MESSAGE    │
+├──────┼───────────────┤
+│    7 │ disk quota    │
+│      │ exceeded on   │
+│      │ volume data   │
+└──────┴───────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "auto fit to width failed")
+
+	// Not a terminal: the table is rendered as usual.
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetAutoFitToWidth(0)
+	table.Append([]string{"a b c d e f g h i j k"})
+	table.Render()
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), "┌───────────────────────┐\n│ a b c d e f g h i j k │\n└───────────────────────┘\n", "auto fit without terminal failed")
+}
+
+func TestRenderString(t *testing.T) {
+	out := &bytes.Buffer{}
+	table := NewWriter(out)
+	table.SetHeader([]string{"name", "note"})
+	table.SetColWidthPercentile(1, 50)
+	table.SetSummaryRow(SummaryCount)
+	table.SetRowHeight(0, 3)
+	table.AppendBulk([][]string{
+		{"a", "short"},
+		{"b", "a much longer note that wraps"},
+		{"c", "mid sized"},
+	})
+
+	first := table.RenderString()
+	checkEqual(t, table.RenderString(), first, "render string is not stable")
+	checkEqual(t, out.Len(), 0, "render string wrote to the table writer")
+
+	table.Render()
+	checkEqual(t, out.String(), first, "render string differs from render")
+}
+
+func TestRenderLeavesTableUnchanged(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"id", "note"})
+	table.SetColWidthPercentile(1, 50)
+	table.SetSummaryRow(SummaryCount)
+	table.SetRowHeight(1, 2)
+	table.AppendBulk([][]string{
+		{"1", "short"},
+		{"2", "a much longer note"},
+		{"3", "mid sized"},
+	})
+	lines := [][][]string{{{"1"}, {"short"}}, {{"2"}, {"a much longer note"}}, {{"3"}, {"mid sized"}}}
+	widths := map[int]int{0: 2, 1: 18}
+
+	table.Render()
+	first := buf.String()
+	checkEqual(t, table.lines, lines, "render changed the lines")
+	checkEqual(t, table.cs, widths, "render changed the column widths")
+
+	buf.Reset()
+	table.Render()
+	checkEqual(t, buf.String(), first, "second render differs")
+}
+
+func TestWideRuneColumns(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"name", "city"})
+	table.AppendBulk([][]string{{"山田", "東京"}, {"José", "Seoul 서울"}})
+	table.Render()
+
+	want := "┌──────┬────────────┐\n" +
+		"│ NAME │    CITY    │\n" +
+		"├──────┼────────────┤\n" +
+		"│ 山田 │ 東京       │\n" +
+		"│ Jose\u0301 │ Seoul 서울 │\n" +
+		"└──────┴────────────┘\n"
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "wide rune columns failed")
+}
+
+func TestColMaxWidth(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetColWidth(5)
+	table.SetColMaxWidth(1, 20)
+	table.SetColMinWidth(2, 8)
+	table.SetColMaxWidth(2, 10)
+	table.SetHeader([]string{"id", "description", "tag"})
+	table.Append([]string{"ab cd", "wraps at twenty columns here", "x"})
+	table.Append([]string{"1", "short", "some long tag"})
+	table.Render()
+
+	want := `┌───────┬──────────────────────┬────────────┐
+│  ID   │     DESCRIPTION      │    TAG     │
+├───────┼──────────────────────┼────────────┤
+│ ab cd │ wraps at twenty      │ x          │
+│       │ columns here         │            │
+│     1 │ short                │ some long  │
+│       │                      │ tag        │
+└───────┴──────────────────────┴────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "column max width failed")
+}
+
+func TestCaption(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"name", "qty"})
+	table.Append([]string{"apples", "3"})
+	table.SetCaption(true, "Stock counted on Monday morning")
+	table.Render()
+
+	want := `┌────────┬─────┐
+│  NAME  │ QTY │
+├────────┼─────┤
+│ apples │   3 │
+└────────┴─────┘
+Stock counted on
+ Monday morning
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "caption failed")
+
+	buf.Reset()
+	table.SetCaptionAlignment(ALIGN_RIGHT)
+	table.Render()
+	want = `┌────────┬─────┐
+│  NAME  │ QTY │
+├────────┼─────┤
+│ apples │   3 │
+└────────┴─────┘
+Stock counted on
+  Monday morning
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "right aligned caption failed")
+
+	buf.Reset()
+	table.SetCaption(false, "Stock counted on Monday morning")
+	table.Render()
+	if strings.Contains(buf.String(), "Stock") {
+		t.Errorf("disabled caption printed:\n%s", buf.String())
+	}
+}
+
+func TestStream(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"id", "message"})
+	table.SetStreamWidths([]int{3, 12})
+	if err := table.StartStream(); err != nil {
+		t.Fatal(err)
+	}
+	want := "┌─────┬──────────────┐\n" +
+		"│ ID  │   MESSAGE    │\n" +
+		"├─────┼──────────────┤\n"
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "stream start failed")
+
+	table.StreamRow([]string{"1", "short"})
+	table.StreamRow([]string{"22", "wrapped to the column width"})
+	table.StreamRow([]string{"333"})
+	table.EndStream()
+
+	want += "│   1 │ short        │\n" +
+		"│  22 │ wrapped to   │\n" +
+		"│     │ the column   │\n" +
+		"│     │ width        │\n" +
+		"│ 333 │              │\n" +
+		"└─────┴──────────────┘\n"
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "stream failed")
+	if _, ok := table.rs[1]; ok || len(table.lines) != 0 {
+		t.Errorf("streamed rows kept: %d lines, heights %v", len(table.lines), table.rs)
+	}
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetColMinWidth(0, 4)
+	table.SetRowLine(true)
+	table.SetBorder(false)
+	if err := table.StartStream(); err != nil {
+		t.Fatal(err)
+	}
+	table.StreamRow([]string{"a"})
+	table.StreamRow([]string{"unbreakable"})
+	table.EndStream()
+	want = "  a     \n" +
+		"────────\n" +
+		"  unb…  \n"
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "stream with row lines failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetHeader([]string{"id"})
+	table.SetStreamWidths([]int{8})
+	var streamed []int
+	table.SetRowRenderedHook(func(rowIdx int) { streamed = append(streamed, rowIdx) })
+	if err := table.StartStream(); err != nil {
+		t.Fatal(err)
+	}
+	table.StreamRow([]string{"a"})
+	table.StreamRow([]string{"b"})
+	table.EndStream()
+	checkEqual(t, streamed, []int{0, 1}, "stream row hook failed")
+	checkEqual(t, table.cs[0], 2, "stream changed the column widths")
+
+	if err := NewWriter(buf).StartStream(); err == nil {
+		t.Error("stream without widths started")
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"name", "notes", "qty"})
+	table.SetColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_LEFT, ALIGN_RIGHT})
+	table.Rich([]string{"Tom & Jerry", "first\nsecond", "3"}, []Colors{{Bold, FgRedColor}})
+	table.Append([]string{"<b>", "\x1b[31mred\x1b[0m", "10"})
+	table.Render()
+	buf.Reset()
+
+	if err := table.RenderHTML(); err != nil {
+		t.Fatal(err)
+	}
+	want := `<table>
+<thead>
+<tr><th>name</th><th>notes</th><th>qty</th></tr>
+</thead>
+<tbody>
+<tr><td>Tom &amp; Jerry</td><td style="text-align:left">first<br>second</td><td style="text-align:right">3</td></tr>
+<tr><td>&lt;b&gt;</td><td style="text-align:left">red</td><td style="text-align:right">10</td></tr>
+</tbody>
+</table>
+`
+	checkEqual(t, buf.String(), want, "HTML table failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.AddColumn("name")
+	table.AddColumn("qty")
+	table.SetColumnAlignmentMap(map[int]int{1: ALIGN_RIGHT})
+	table.AppendNamed(map[string]string{"name": "apple", "qty": "3"})
+	if err := table.RenderHTML(); err != nil {
+		t.Fatal(err)
+	}
+	want = `<table>
+<thead>
+<tr><th>name</th><th>qty</th></tr>
+</thead>
+<tbody>
+<tr><td>apple</td><td style="text-align:right">3</td></tr>
+</tbody>
+</table>
+`
+	checkEqual(t, buf.String(), want, "HTML table with added columns failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetHeader([]string{"id", "qty"})
+	table.Append([]string{"1", "2.50"})
+	table.Append([]string{"b", "3"})
+	if err := table.RenderHTML(); err != nil {
+		t.Fatal(err)
+	}
+	want = `<table>
+<thead>
+<tr><th>id</th><th>qty</th></tr>
+</thead>
+<tbody>
+<tr><td style="text-align:right">1</td><td style="text-align:right">2.50</td></tr>
+<tr><td>b</td><td style="text-align:right">3</td></tr>
+</tbody>
+</table>
+`
+	checkEqual(t, buf.String(), want, "HTML table with a numeric first row failed")
+}
+
+func TestStructTagSkip(t *testing.T) {
+	type item struct {
+		Name    string
+		version int  `tablewriter:"-"`
+		Amount  int  `tablewriter:",align=left"`
+		Dirty   bool `tablewriter:"-"`
+	}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	items := []item{{"a", 1, 10, true}, {"bcd", 2, 2, false}}
+	if err := table.SetStructs(items); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `┌──────┬────────┐
+│ NAME │ AMOUNT │
+├──────┼────────┤
+│ a    │ 10     │
+│ bcd  │ 2      │
+└──────┴────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "struct tag skip failed")
+
+	type skipped struct {
+		Name string `tablewriter:"-"`
+	}
+	if err := NewWriter(buf).SetStructs([]skipped{{"a"}}); err == nil {
+		t.Error("struct with every field skipped should fail")
+	}
+}
+
+func TestStructEmbedded(t *testing.T) {
+	type Audit struct {
+		Owner string
+		Rev   int `tablewriter:"Revision,align=left"`
+	}
+	type Point struct {
+		X, Y int
+	}
+	type item struct {
+		Name string
+		Audit
+		*Point
+		Where Point
+		Other Audit `tablewriter:"-"`
+	}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	items := []item{
+		{Name: "a", Audit: Audit{"bob", 3}, Point: &Point{1, 2}, Where: Point{5, 6}},
+		{Name: "b", Audit: Audit{"eve", 12}},
+	}
+	if err := table.SetStructs(items); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `┌──────┬───────┬──────────┬─────┬─────┬───────┐
+│ NAME │ OWNER │ REVISION │  X  │  Y  │ WHERE │
+├──────┼───────┼──────────┼─────┼─────┼───────┤
+│ a    │ bob   │ 3        │   1 │   2 │ {5 6} │
+│ b    │ eve   │ 12       │ nil │ nil │ {0 0} │
+└──────┴───────┴──────────┴─────┴─────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "embedded struct failed")
+}
+
+func TestStructTagOrder(t *testing.T) {
+	type item struct {
+		Name   string `tablewriter:",order=2"`
+		Amount int    `tablewriter:"Qty,order=0,align=left"`
+		Note   string
+		Code   string
+	}
+
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	if err := table.SetStructs([]item{{"a", 10, "x", "c1"}, {"bcd", 2, "y", "c2"}}); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `┌─────┬──────┬──────┬──────┐
+│ QTY │ NOTE │ NAME │ CODE │
+├─────┼──────┼──────┼──────┤
+│ 10  │ x    │ a    │ c1   │
+│ 2   │ y    │ bcd  │ c2   │
+└─────┴──────┴──────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "struct tag order failed")
+
+	type conflict struct {
+		A string `tablewriter:",order=1"`
+		B string `tablewriter:",order=1"`
+	}
+	err := NewWriter(buf).SetStructs([]conflict{{"a", "b"}})
+	if err == nil || err.Error() != `column "B": order 1 already taken by column "A"` {
+		t.Errorf("conflicting orders should fail, got %v", err)
+	}
+
+	type outOfRange struct {
+		A string `tablewriter:",order=2"`
+		B string
+	}
+	err = NewWriter(buf).SetStructs([]outOfRange{{"a", "b"}})
+	if err == nil || err.Error() != `column "A": order 2 out of range` {
+		t.Errorf("out of range order should fail, got %v", err)
+	}
+
+	type invalid struct {
+		A string `tablewriter:",order=first"`
+	}
+	err = NewWriter(buf).SetStructs([]invalid{{"a"}})
+	if err == nil || err.Error() != `field A: invalid order "first"` {
+		t.Errorf("invalid order should fail, got %v", err)
+	}
+}
+
+func TestRenderVertical(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeader([]string{"id", "name", "address"})
+	table.Append([]string{"1", "Ana", "12 Main St\nSpringfield"})
+	table.AppendSpacer(1)
+	table.Append([]string{"2", "", "unknown"})
+	table.RenderVertical()
+
+	want := `*************************** 1. row ***************************
+     id: 1
+   name: Ana
+address: 12 Main St
+         Springfield
+*************************** 2. row ***************************
+     id: 2
+   name:
+address: unknown
+`
+	checkEqual(t, buf.String(), want, "vertical rendering failed")
+}
+
+func TestWrapMode(t *testing.T) {
+	render := func(mode WrapMode) string {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetColWidth(10)
+		table.SetWrapMode(mode)
+		table.SetHeader([]string{"link"})
+		table.Append([]string{"go to https://example.com/docs"})
+		table.Render()
+		return ansi.ReplaceAllString(buf.String(), "")
+	}
+
+	want := `┌──────────────────────────┐
+│           LINK           │
+├──────────────────────────┤
+│ go to                    │
+│ https://example.com/docs │
+└──────────────────────────┘
+`
+	checkEqual(t, render(WrapWord), want, "word wrap mode failed")
+
+	want = `┌────────────┐
+│    LINK    │
+├────────────┤
+│ go to      │
+│ https://ex │
+│ ample.com/ │
+│ docs       │
+└────────────┘
+`
+	checkEqual(t, render(WrapHard), want, "hard wrap mode failed")
+
+	want = `┌────────────────────────────────┐
+│              LINK              │
+├────────────────────────────────┤
+│ go to https://example.com/docs │
+└────────────────────────────────┘
+`
+	checkEqual(t, render(WrapNone), want, "no wrap mode failed")
+}
+
+func TestRTL(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetRTL(true)
+	table.SetHeader([]string{"id", "name", "city"})
+	table.SetColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_DEFAULT, ALIGN_LEFT})
+	table.Append([]string{"1", "שרה", "Haifa"})
+	table.Append([]string{"22", "דוד כהן", "Eilat"})
+	table.SetFooter([]string{"2", "", ""})
+	table.Render()
+
+	want := `┌───────┬─────────┬────┐
+│ CITY  │  NAME   │ ID │
+├───────┼─────────┼────┤
+│ Haifa │     שרה │ 1  │
+│ Eilat │ דוד כהן │ 22 │
+├───────┼─────────┼────┤
+│       │         │ 2  │
+└───────┴─────────┴────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "right to left failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetRTL(true)
+	table.SetHeaderSpans([]int{2, 1})
+	table.SetHeader([]string{"person", "n"})
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.AppendBulk([][]string{{"a", "b", "1"}, {"a", "c", "2"}})
+	table.Render()
+
+	want = `┌───┬────────┐
+│ N │ PERSON │
+├───┼───┬────┤
+│ 1 │ b │  a │
+│ 2 │ c │    │
+└───┴───┴────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "right to left with spans and merged cells failed")
+}
+
+func TestSetColumnColors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorder(false)
+	table.SetBorderStyle(ASCIIBorderStyle)
+	table.SetColumnColors(1, Colors{FgRedColor})
+	table.Append([]string{"a", "red"})
+	table.Rich([]string{"b", "blue"}, []Colors{nil, {FgBlueColor}})
+	table.Append([]string{"c", "re"})
+	table.Render()
+
+	red := func(s string) string { return "\x1b[31m" + s + "\x1b[0m" }
+	want := "  a | " + red("red") + "   \n" +
+		"  b | \x1b[34mblue\x1b[0m  \n" +
+		"  c | " + red("re") + "    \n"
+	checkEqual(t, buf.String(), want, "column colors failed")
+}
+
+func TestRowStripe(t *testing.T) {
+	render := func(stripe bool) string {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetBorder(false)
+		table.SetBorderStyle(ASCIIBorderStyle)
+		if stripe {
+			table.SetRowStripe(nil, Colors{BgBlackColor})
+		}
+		table.Append([]string{"a", "1"})
+		table.Rich([]string{"b", "2"}, []Colors{{FgRedColor}})
+		table.Append([]string{"c", "3"})
+		table.Render()
+		return buf.String()
+	}
+
+	want := "  a | 1  \n" +
+		"  \x1b[31mb\x1b[0m | 2  \n" +
+		"  c | 3  \n"
+	checkEqual(t, render(false), want, "rows without stripe failed")
+
+	want = "  a | 1  \n" +
+		"\x1b[40m  \x1b[31mb\x1b[0m\x1b[40m | 2  \x1b[0m\n" +
+		"  c | 3  \n"
+	checkEqual(t, render(true), want, "row stripe failed")
+}
+
+func TestAutoIndex(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetAutoIndex(true)
+	table.SetHeader([]string{"name", "qty"})
+	table.SetColumnAlignment([]int{ALIGN_CENTER, ALIGN_LEFT})
+	for i := 0; i < 10; i++ {
+		table.Append([]string{string(rune('a' + i)), fmt.Sprint(i * 5)})
+		if i == 1 {
+			table.AppendSpacer(1)
+		}
+	}
+	table.Render()
+
+	want := `┌────┬──────┬─────┐
+│ #  │ NAME │ QTY │
+├────┼──────┼─────┤
+│  1 │  a   │ 0   │
+│  2 │  b   │ 5   │
+│    │      │     │
+│  3 │  c   │ 10  │
+│  4 │  d   │ 15  │
+│  5 │  e   │ 20  │
+│  6 │  f   │ 25  │
+│  7 │  g   │ 30  │
+│  8 │  h   │ 35  │
+│  9 │  i   │ 40  │
+│ 10 │  j   │ 45  │
+└────┴──────┴─────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "auto index failed")
+	if len(table.cs) != 2 || len(table.headers) != 2 {
+		t.Errorf("auto index changed the table: %d columns, %d headers", len(table.cs), len(table.headers))
+	}
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetAutoIndex(true)
+	table.SetAutoIndexHeader("row")
+	table.SetHeader([]string{"name"})
+	table.Append([]string{"a"})
+	table.Render()
+
+	want = `┌─────┬──────┐
+│ ROW │ NAME │
+├─────┼──────┤
+│   1 │ a    │
+└─────┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "auto index header failed")
+
+	// Rows without cells are numbered, unlike spacers
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetAutoIndex(true)
+	table.SetPadShortRows(true)
+	table.SetHeader([]string{"name"})
+	table.Append([]string{"a"})
+	table.Append([]string{})
+	table.AppendSpacer(1)
+	table.Append([]string{"b"})
+	table.Render()
+
+	want = `┌───┬──────┐
+│ # │ NAME │
+├───┼──────┤
+│ 1 │ a    │
+│ 2 │      │
+│   │      │
+│ 3 │ b    │
+└───┴──────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "auto index of an empty row failed")
+}
+
+func TestNumericPatterns(t *testing.T) {
+	render := func(setup func(*Table)) string {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetHeader([]string{"zip", "total"})
+		setup(table)
+		table.Append([]string{"02139", "$1,200"})
+		table.Append([]string{"94110-1234", "$15"})
+		table.Render()
+		return ansi.ReplaceAllString(buf.String(), "")
+	}
+
+	want := `┌────────────┬────────┐
+│    ZIP     │ TOTAL  │
+├────────────┼────────┤
+│      02139 │ $1,200 │
+│ 94110-1234 │ $15    │
+└────────────┴────────┘
+`
+	checkEqual(t, render(func(*Table) {}), want, "default numeric alignment failed")
+
+	want = `┌────────────┬────────┐
+│    ZIP     │ TOTAL  │
+├────────────┼────────┤
+│ 02139      │ $1,200 │
+│ 94110-1234 │ $15    │
+└────────────┴────────┘
+`
+	checkEqual(t, render(func(table *Table) {
+		table.SetAutoNumericAlignment(false)
+	}), want, "disabled numeric alignment failed")
+
+	want = `┌────────────┬────────┐
+│    ZIP     │ TOTAL  │
+├────────────┼────────┤
+│ 02139      │ $1,200 │
+│ 94110-1234 │    $15 │
+└────────────┴────────┘
+`
+	checkEqual(t, render(func(table *Table) {
+		table.SetNumericPatterns(regexp.MustCompile(`^\$[\d,]+$`))
+	}), want, "numeric patterns failed")
+}
+
+func TestNumericPatternsShared(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetBorderStyle(ASCIIBorderStyle)
+	table.SetNumericPatterns(regexp.MustCompile(`^\d+$`))
+	table.SetNumberFormat(func(s string) string { return s + ".0" })
+	table.SetSummaryRow(SummaryNone, SummarySum)
+	table.SetHeader([]string{"id", "qty"})
+	table.Append([]string{"a1", "2"})
+	table.Append([]string{"b2", "1,000"})
+	table.Render()
+
+	want := `+----+-------+
+| ID |  QTY  |
++----+-------+
+| a1 | 2.0   |
+| b2 | 1,000 |
++----+-------+
+|    |       |
++----+-------+
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "numeric patterns for format and sum failed")
+}
+
+func TestNumberFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetNumberFormat(func(s string) string {
+		f, err := strconv.ParseFloat(strings.Replace(s, ",", "", -1), 64)
+		if err != nil {
+			return s
+		}
+		whole := strconv.FormatInt(int64(f), 10)
+		for i := len(whole) - 3; i > 0; i -= 3 {
+			whole = whole[:i] + "," + whole[i:]
+		}
+		return whole + strconv.FormatFloat(f-float64(int64(f)), 'f', 2, 64)[1:]
+	})
+	table.SetHeader([]string{"item", "price"})
+	table.Append([]string{"car", "1234567.5"})
+	table.Append([]string{"pen", " 2 "})
+	table.Append([]string{"1234", "n/a"})
+	table.Render()
+
+	want := `┌──────────┬──────────────┐
+│   ITEM   │    PRICE     │
+├──────────┼──────────────┤
+│ car      │ 1,234,567.50 │
+│ pen      │         2.00 │
+│ 1,234.00 │ n/a          │
+└──────────┴──────────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "number format failed")
+}
+
+func TestHeaderSpan(t *testing.T) {
+	buf := &bytes.Buffer{}
+	table := NewWriter(buf)
+	table.SetHeaderSpan([]HeaderCell{{"", 1}, {"2023", 3}})
+	table.SetHeader([]string{"region", "jan", "feb", "mar", "total"})
+	table.Append([]string{"north", "1", "2", "3", "6"})
+	table.Render()
+
+	want := `┌────────┬─────────────────┬───────┐
+│        │      2023       │       │
+├────────┼─────┬─────┬─────┼───────┤
+│ REGION │ JAN │ FEB │ MAR │ TOTAL │
+├────────┼─────┼─────┼─────┼───────┤
+│ north  │   1 │   2 │   3 │     6 │
+└────────┴─────┴─────┴─────┴───────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "header span failed")
+
+	buf.Reset()
+	table = NewWriter(buf)
+	table.SetHeaderSpan([]HeaderCell{{"first quarter", 2}})
+	table.SetHeader([]string{"jan", "feb"})
+	table.Append([]string{"1", "2"})
+	table.Render()
+
+	want = `┌───────────────┐
+│ FIRST QUARTER │
+├─────┬─────────┤
+│ JAN │   FEB   │
+├─────┼─────────┤
+│   1 │       2 │
+└─────┴─────────┘
+`
+	checkEqual(t, ansi.ReplaceAllString(buf.String(), ""), want, "wide header span failed")
+}
+
+func TestWrapCache(t *testing.T) {
+	render := func(cache bool) (string, int) {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		table.SetWrapCache(cache)
+		calls := 0
+		table.SetColumnFormatter(1, func(s string) string {
+			calls++
+			return s
+		})
+		table.SetColWidth(10)
+		table.SetHeader([]string{"id", "category"})
+		for i := 0; i < 6; i++ {
+			table.Append([]string{fmt.Sprint(i), []string{"fruit and vegetables", "dairy"}[i%2]})
+			if i == 3 {
+				table.SetColWidth(12)
+			}
+		}
+		table.Render()
+		return ansi.ReplaceAllString(buf.String(), ""), calls
+	}
+
+	want := `┌────┬──────────────┐
+│ ID │   CATEGORY   │
+├────┼──────────────┤
+│  0 │ fruit and    │
+│    │ vegetables   │
+│  1 │ dairy        │
+│  2 │ fruit and    │
+│    │ vegetables   │
+│  3 │ dairy        │
+│  4 │ fruit and    │
+│    │ vegetables   │
+│  5 │ dairy        │
+└────┴──────────────┘
+`
+	got, calls := render(false)
+	checkEqual(t, got, want, "rendering without wrap cache failed")
+	checkEqual(t, calls, 6)
+
+	got, calls = render(true)
+	checkEqual(t, got, want, "rendering with wrap cache failed")
+	checkEqual(t, calls, 4)
+}
+
+func TestWidthAsRendered(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*Table)
+	}{
+		{"hidden column", func(table *Table) { table.SetHideEmptyColumns(true) }},
+		{"auto fit", func(table *Table) { table.SetAutoFitToWidth(20) }},
+		{"auto index", func(table *Table) { table.SetAutoIndex(true) }},
+		{"right to left", func(table *Table) { table.SetRTL(true) }},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		table := NewWriter(buf)
+		tt.setup(table)
+		table.Append([]string{"some long text here", "", "500"})
+		table.Render()
+
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			checkEqual(t, table.Width(), DisplayWidth(line), tt.name+" width failed")
+		}
+	}
+}
+
+func TestComputeWidthsAsRendered(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(*Table)
+		widths []int
+	}{
+		{"auto index", func(table *Table) { table.SetAutoIndex(true) }, []int{1, 1, 5, 0}},
+		{"hidden column", func(table *Table) { table.SetHideEmptyColumns(true) }, []int{1, 5}},
+		{"auto fit", func(table *Table) { table.SetAutoFitToWidth(12) }, []int{1, 1, 0}},
+	}
+	for _, tt := range tests {
+		table := NewWriter(&bytes.Buffer{})
+		tt.setup(table)
+		table.Append([]string{"a", "b c d", ""})
+		checkEqual(t, table.ComputeWidths(), tt.widths, tt.name+" widths failed")
+		checkEqual(t, table.Grid().ColumnWidths(), tt.widths, tt.name+" grid widths failed")
+		checkEqual(t, table.Grid().Columns(), len(tt.widths), tt.name+" grid columns failed")
+		checkEqual(t, table.Width(), DisplayWidth(strings.SplitN(table.RenderString(), "\n", 2)[0]), tt.name+" width failed")
+	}
+}
+
+func TestRenderPagedAsRendered(t *testing.T) {
+	pages := func(table *Table) []string {
+		buf := &bytes.Buffer{}
+		if err := table.RenderPaged(buf, 7); err != nil {
+			t.Fatal(err)
+		}
+		return strings.Split(buf.String(), "\f")
+	}
+
+	fitted := NewWriter(&bytes.Buffer{})
+	fitted.SetHeader([]string{"name", "value"})
+	fitted.AppendBulk([][]string{{"a", "one two three"}, {"b", "four five six"}})
+	fitted.SetAutoFitToWidth(16)
+
+	grouped := NewWriter(&bytes.Buffer{})
+	grouped.SetHeader([]string{"a", "b"})
+	grouped.SetHeaderSpan([]HeaderCell{{Text: "ab", Span: 2}})
+	grouped.AppendBulk([][]string{{"1", "2"}, {"3", "4"}})
+
+	for name, table := range map[string]*Table{"auto fit": fitted, "header span": grouped} {
+		got := pages(table)
+		if len(got) < 2 {
+			t.Errorf("%s: got %d page, want the rows split over pages", name, len(got))
+		}
+		for _, page := range got {
+			if n := strings.Count(page, "\n"); n > 7 {
+				t.Errorf("%s: page of %d lines is taller than 7:\n%s", name, n, page)
+			}
+		}
+	}
+}
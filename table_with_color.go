@@ -62,6 +62,15 @@ const (
 
 type Colors []int
 
+// LegendEntry labels a color used in the table
+type LegendEntry struct {
+    Color Colors
+    Label string
+}
+
+// Swatch printed before every label of the color legend
+const SWATCH = "■"
+
 func startFormat(seq string) string {
     return fmt.Sprintf("%s[%sm", ESC, seq)
 }
@@ -121,6 +130,57 @@ func (t *Table) SetColumnColor(colors ...Colors) {
     }
 }
 
+// Set the colors of every cell of column col
+// Cells given their own colors with Rich keep them.
+func (t *Table) SetColumnColors(col int, colors Colors) {
+    if t.columnColors == nil {
+        t.columnColors = make(map[int]Colors)
+    }
+    t.columnColors[col] = colors
+}
+
+// Set the colors of the even and odd rows
+// Every line of a row is printed on the colors of its stripe, from border
+// to border, under the colors of its cells. Rows are counted from 0, so the
+// first row is even, and empty colors leave the rows as they are.
+func (t *Table) SetRowStripe(even, odd Colors) {
+    t.stripeEven = even
+    t.stripeOdd = odd
+}
+
+// Print a line of row rowIdx on the colors of its stripe, set again after
+// every reset inside it
+func (t *Table) stripeLine(rowIdx int, line string) string {
+    colors := t.stripeEven
+    if rowIdx%2 != 0 {
+        colors = t.stripeOdd
+    }
+    if rowIdx < 0 || len(colors) == 0 {
+        return line
+    }
+    seq := startFormat(makeSequence(colors))
+    return seq + strings.Replace(line, stopFormat(), stopFormat()+seq, -1) + stopFormat()
+}
+
+// Set the color legend
+// The legend is printed below the table as a single line of colored
+// swatches followed by their labels. No legend is printed when empty.
+func (t *Table) SetColorLegend(legend []LegendEntry) {
+    t.legend = legend
+}
+
+// Print the color legend, if any
+func (t *Table) printLegend() {
+    if len(t.legend) == 0 || t.morePages {
+        return
+    }
+    entries := make([]string, 0, len(t.legend))
+    for _, e := range t.legend {
+        entries = append(entries, format(SWATCH, e.Color)+SPACE+e.Label)
+    }
+    fmt.Fprint(t.out, strings.Join(entries, SPACE+SPACE), t.lineEnd())
+}
+
 func Color(colors ...int) []int {
     return colors
 }
@@ -11,16 +11,193 @@ import (
 	"math"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 )
 
-var ansi = regexp.MustCompile("\033\\[(?:[0-9]{1,3}(?:;[0-9]{1,3})*)?[m|K]")
+// ansi matches the escape sequences that take no space on the terminal: CSI
+// sequences such as SGR colors, and OSC sequences such as OSC 8 hyperlinks.
+var ansi = regexp.MustCompile(ansiPattern)
 
+// ansiPrefix matches an escape sequence at the start of a string.
+var ansiPrefix = regexp.MustCompile("^(?:" + ansiPattern + ")")
+
+const ansiPattern = `\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\))`
+
+// DisplayWidth returns the number of terminal columns str occupies. ANSI
+// escape sequences take no space and East Asian wide runes take two columns.
 func DisplayWidth(str string) int {
 	return runewidth.StringWidth(ansi.ReplaceAllLiteralString(str, ""))
 }
 
+// Expand the tabs of each line of str to spaces, up to the next multiple of
+// width measured with DisplayWidth
+func expandTabs(str string, width int) string {
+	if !strings.Contains(str, "\t") {
+		return str
+	}
+	lines := getLines(str)
+	for i, line := range lines {
+		parts := strings.Split(line, "\t")
+		var b strings.Builder
+		col := 0
+		for j, part := range parts {
+			b.WriteString(part)
+			if j == len(parts)-1 {
+				break
+			}
+			col += DisplayWidth(part)
+			n := width - col%width
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, nl)
+}
+
+// Truncate str to width columns, replacing the end with tail when it is cut.
+// Escape sequences are never split, and those after the cut are kept so that
+// colors are still reset.
+func truncate(str string, width int, tail string) string {
+	if DisplayWidth(str) <= width {
+		return str
+	}
+	limit := width - DisplayWidth(tail)
+
+	var kept, escapes strings.Builder
+	w := 0
+	cut := false
+	for len(str) > 0 {
+		if loc := ansiPrefix.FindStringIndex(str); loc != nil {
+			if cut {
+				escapes.WriteString(str[:loc[1]])
+			} else {
+				kept.WriteString(str[:loc[1]])
+			}
+			str = str[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(str)
+		if rw := runewidth.RuneWidth(r); !cut && w+rw <= limit {
+			kept.WriteRune(r)
+			w += rw
+		} else {
+			cut = true
+		}
+		str = str[size:]
+	}
+	return kept.String() + tail + escapes.String()
+}
+
+// truncateStart is like truncate, but cuts the start of str instead of its
+// end, so that tail starts the result. Escape sequences of the part cut are
+// kept before tail.
+func truncateStart(str string, width int, tail string) string {
+	if DisplayWidth(str) <= width {
+		return str
+	}
+	limit := width - DisplayWidth(tail)
+
+	// Split str into runes and escape sequences, to walk it from the end.
+	var tokens []string
+	for len(str) > 0 {
+		if loc := ansiPrefix.FindStringIndex(str); loc != nil {
+			tokens = append(tokens, str[:loc[1]])
+			str = str[loc[1]:]
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(str)
+		tokens = append(tokens, str[:size])
+		str = str[size:]
+	}
+
+	// Keep the tokens fitting from the end, and the escape sequences of the
+	// others, marking the cut runes as empty.
+	w := 0
+	cut := false
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if ansiPrefix.MatchString(tokens[i]) {
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(tokens[i])
+		if rw := runewidth.RuneWidth(r); !cut && w+rw <= limit {
+			w += rw
+		} else {
+			cut = true
+			tokens[i] = ""
+		}
+	}
+
+	var escapes, kept strings.Builder
+	for _, token := range tokens {
+		if kept.Len() == 0 && ansiPrefix.MatchString(token) {
+			escapes.WriteString(token)
+		} else {
+			kept.WriteString(token)
+		}
+	}
+	return escapes.String() + tail + kept.String()
+}
+
+// truncateAligned cuts str to width like truncate, from the side opposite
+// to its alignment: the start of cells aligned right, both ends of cells
+// centered, and the end of the others, so that the part lined up with the
+// other cells stays visible.
+func truncateAligned(str string, width int, tail string, align int) string {
+	switch align {
+	case ALIGN_RIGHT:
+		return truncateStart(str, width, tail)
+	case ALIGN_CENTER:
+		total := DisplayWidth(str)
+		if total <= width {
+			return str
+		}
+		// Cut half of the excess from the end, and the rest from the start.
+		excess := total - (width - 2*DisplayWidth(tail))
+		str = truncate(str, total-excess/2+DisplayWidth(tail), tail)
+		return truncateStart(str, width, tail)
+	}
+	return truncate(str, width, tail)
+}
+
+// Zero width characters
+const (
+	zeroWidthSpace     = '\u200B'
+	zeroWidthNonJoiner = '\u200C'
+	zeroWidthJoiner    = '\u200D'
+	wordJoiner         = '\u2060'
+	byteOrderMark      = '\uFEFF'
+)
+
+// stripZeroWidth removes the zero width spaces of str, and its zero width
+// joiners and non-joiners that join nothing. Joiners between two non-ASCII
+// runes, as in emoji sequences or in scripts shaped with them, are kept
+// unless joiners is true.
+func stripZeroWidth(str string, joiners bool) string {
+	if !strings.ContainsAny(str, "\u200B\u200C\u200D\u2060\uFEFF") {
+		return str
+	}
+	rs := []rune(str)
+	var b strings.Builder
+	for i, r := range rs {
+		switch r {
+		case zeroWidthSpace, wordJoiner, byteOrderMark:
+			continue
+		case zeroWidthJoiner, zeroWidthNonJoiner:
+			if joiners || i == 0 || i == len(rs)-1 ||
+				rs[i-1] < utf8.RuneSelf || rs[i+1] < utf8.RuneSelf {
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // Simple Condition for string
 // Returns value based on condition
 func ConditionString(cond bool, valid, inValid string) string {
@@ -37,6 +214,22 @@ func isNumOrSpace(r rune) bool {
 // Format Table Header
 // Replace _ , . and spaces
 func Title(name string) string {
+	return strings.ToUpper(titleWords(name))
+}
+
+// TitleLanguage is like Title, but upper cases name with the rules of the
+// language tag, such as "i" to "İ" in Turkish or "ß" to "SS" in German.
+func TitleLanguage(name string, tag language.Tag) string {
+	if tag == language.Und {
+		return Title(name)
+	}
+	// The standard library only has the special cases of Turkish and Azeri,
+	// and none of the mappings to more runes, such as "ß" to "SS".
+	return cases.Upper(tag).String(titleWords(name))
+}
+
+// titleWords replaces the _ and . of name with spaces, and trims the spaces.
+func titleWords(name string) string {
 	origLen := len(name)
 	rs := []rune(name)
 	for i, r := range rs {
@@ -57,15 +250,33 @@ func Title(name string) string {
 		// empty lines in multi-line headers/footers.
 		name = " "
 	}
-	return strings.ToUpper(name)
+	return name
 }
 
+// Bias is the side given the odd space when centering a string
+type Bias int
+
+const (
+	BiasRight Bias = iota
+	BiasLeft
+)
+
 // Pad String
 // Attempts to place string in the center
 func Pad(s, pad string, width int) string {
+	return PadBias(s, pad, width, BiasRight)
+}
+
+// Pad String with Bias
+// Attempts to place string in the center, giving the odd space, if any,
+// to the side of bias
+func PadBias(s, pad string, width int, bias Bias) string {
 	gap := width - DisplayWidth(s)
 	if gap > 0 {
 		gapLeft := int(math.Ceil(float64(gap / 2)))
+		if bias == BiasLeft {
+			gapLeft = gap - gapLeft
+		}
 		gapRight := gap - gapLeft
 		return strings.Repeat(string(pad), gapLeft) + s + strings.Repeat(string(pad), gapRight)
 	}
@@ -91,3 +302,14 @@ func PadLeft(s, pad string, width int) string {
 	}
 	return s
 }
+
+// trimLines trims the white space at the end of each line of s.
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		cr := strings.HasSuffix(line, "\r")
+		line = strings.TrimRight(strings.TrimSuffix(line, "\r"), SPACE)
+		lines[i] = line + ConditionString(cr, "\r", "")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,59 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderVertical renders every row as a record of "Header: value" lines, one
+// per column, below a separator numbering the record, like the \G output of
+// MySQL. Headers are printed as given, right aligned so that the colons line
+// up, and the further lines of multi-line values are aligned under the
+// first. Spacer rows are left out.
+func (t *Table) RenderVertical() {
+	headers := make([]string, len(t.cs))
+	width := 0
+	for y := range headers {
+		if y < len(t.headers) && len(t.headerSpans) == 0 {
+			headers[y] = strings.Join(t.headers[y], SPACE)
+		}
+		if w := DisplayWidth(headers[y]); w > width {
+			width = w
+		}
+	}
+	indent := strings.Repeat(SPACE, width+1)
+
+	record := 0
+	for i, columns := range t.lines {
+		if t.isSpacer(i) {
+			continue
+		}
+		record++
+		stars := strings.Repeat("*", 27)
+		fmt.Fprintf(t.out, "%s %d. row %s%s", stars, record, stars, t.lineEnd())
+		for y, header := range headers {
+			cell := []string{""}
+			if y < len(columns) && len(columns[y]) > 0 {
+				cell = columns[y]
+			}
+			prefix := PadLeft(header, SPACE, width) + ":"
+			for k, line := range cell {
+				if k > 0 {
+					prefix = indent
+				}
+				if line == "" {
+					fmt.Fprint(t.out, strings.TrimRight(prefix, SPACE), t.lineEnd())
+					continue
+				}
+				fmt.Fprint(t.out, prefix, SPACE, line, t.lineEnd())
+			}
+		}
+	}
+}
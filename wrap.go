@@ -10,6 +10,7 @@ package tablewriter
 import (
 	"math"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mattn/go-runewidth"
 )
@@ -22,29 +23,132 @@ var (
 const defaultPenalty = 1e5
 
 // Wrap wraps s into a paragraph of lines of length lim, with minimal
-// raggedness.
+// raggedness. Words are measured with DisplayWidth, so escape sequences take
+// no space, and lines only break between words, so escape sequences are
+// never split.
 func WrapString(s string, lim int) ([]string, int) {
+	lines, lim, _ := wrapString(s, lim)
+	return lines, lim
+}
+
+// WrapStringWithWidth is like WrapString, but returns the display width of
+// the widest line instead of the limit, which is the width the lines take
+// once printed.
+func WrapStringWithWidth(s string, width int) ([]string, int) {
+	lines, _, width := wrapString(s, width)
+	return lines, width
+}
+
+// WrapStringHard is like WrapString, but breaks the words wider than lim at
+// exactly lim columns instead of letting them overflow, so that no line is
+// wider than lim, unless lim is narrower than a single rune. Escape
+// sequences are never split.
+func WrapStringHard(s string, lim int) ([]string, int) {
+	return WrapString(breakWords(s, lim), lim)
+}
+
+// breakWords splits the words of s wider than lim into pieces of lim
+// columns, separated by spaces. Line breaks become spaces, as in WrapString.
+func breakWords(s string, lim int) string {
+	words := strings.Split(strings.Replace(s, nl, sp, -1), sp)
+	for i, word := range words {
+		if DisplayWidth(word) > lim {
+			words[i] = strings.Join(splitWidth(word, lim), sp)
+		}
+	}
+	return strings.Join(words, sp)
+}
+
+// splitWidth cuts str into pieces of width columns, the last one possibly
+// narrower. Escape sequences are kept with the rune that follows them.
+func splitWidth(str string, width int) []string {
+	var (
+		pieces []string
+		piece  strings.Builder
+		w      int
+	)
+	for len(str) > 0 {
+		if loc := ansiPrefix.FindStringIndex(str); loc != nil {
+			piece.WriteString(str[:loc[1]])
+			str = str[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(str)
+		rw := runewidth.RuneWidth(r)
+		if w > 0 && w+rw > width {
+			pieces = append(pieces, piece.String())
+			piece.Reset()
+			w = 0
+		}
+		piece.WriteString(str[:size])
+		w += rw
+		str = str[size:]
+	}
+	return append(pieces, piece.String())
+}
+
+// wrapString wraps s as WrapString does, and returns the lines, the limit
+// they were wrapped to and the display width of the widest.
+func wrapString(s string, lim int) ([]string, int, int) {
 	words := strings.Split(strings.Replace(s, nl, sp, -1), sp)
 	var lines []string
 	max := 0
 	for _, v := range words {
-		max = runewidth.StringWidth(v)
+		max = DisplayWidth(v)
 		if max > lim {
 			lim = max
 		}
 	}
+	width := 0
 	for _, line := range WrapWords(words, 1, lim, defaultPenalty) {
-		lines = append(lines, strings.Join(line, sp))
+		text := strings.Join(line, sp)
+		if w := DisplayWidth(text); w > width {
+			width = w
+		}
+		lines = append(lines, text)
 	}
+	return lines, lim, width
+}
+
+// WrapStringTabs is like WrapString, but first expands the tabs of s to
+// spaces up to the next multiple of tabWidth, as cells are when a tab width
+// is set, so that tabs are measured by the width they take once printed.
+// Tabs are left as they are when tabWidth is not positive. A line broken
+// inside the spaces of a tab does not keep them at its end, nor at the
+// start of the next line.
+func WrapStringTabs(s string, lim, tabWidth int) ([]string, int) {
+	lines, lim, _ := wrapStringTabs(s, lim, tabWidth)
 	return lines, lim
 }
 
+// wrapStringTabs wraps s as WrapStringTabs does, and returns the lines, the
+// limit they were wrapped to and the display width of the widest.
+func wrapStringTabs(s string, lim, tabWidth int) ([]string, int, int) {
+	if tabWidth <= 0 {
+		return wrapString(s, lim)
+	}
+	lines, lim, _ := wrapString(expandTabs(s, tabWidth), lim)
+	width := 0
+	for i := range lines {
+		if i > 0 {
+			lines[i] = strings.TrimLeft(lines[i], sp)
+		}
+		if i < len(lines)-1 {
+			lines[i] = strings.TrimRight(lines[i], sp)
+		}
+		if w := DisplayWidth(lines[i]); w > width {
+			width = w
+		}
+	}
+	return lines, lim, width
+}
+
 // WrapWords is the low-level line-breaking algorithm, useful if you need more
 // control over the details of the text wrapping process. For most uses,
 // WrapString will be sufficient and more convenient.
 //
 // WrapWords splits a list of words into lines with minimal "raggedness",
-// treating each rune as one unit, accounting for spc units between adjacent
+// measuring each word with DisplayWidth, accounting for spc units between adjacent
 // words on each line, and attempting to limit lines to lim units. Raggedness
 // is the total error over all lines, where error is the square of the
 // difference of the length of the line and lim. Too-long lines (which only
@@ -56,9 +160,9 @@ func WrapWords(words []string, spc, lim, pen int) [][]string {
 	length := make([][]int, n)
 	for i := 0; i < n; i++ {
 		length[i] = make([]int, n)
-		length[i][i] = runewidth.StringWidth(words[i])
+		length[i][i] = DisplayWidth(words[i])
 		for j := i + 1; j < n; j++ {
-			length[i][j] = length[i][j-1] + spc + runewidth.StringWidth(words[j])
+			length[i][j] = length[i][j-1] + spc + DisplayWidth(words[j])
 		}
 	}
 	nbrk := make([]int, n)
@@ -97,3 +201,13 @@ func WrapWords(words []string, spc, lim, pen int) [][]string {
 func getLines(s string) []string {
 	return strings.Split(s, nl)
 }
+
+// getRunes decomposes a string into a slice holding one rune per string,
+// ignoring line breaks.
+func getRunes(s string) []string {
+	var runes []string
+	for _, r := range strings.Replace(s, nl, "", -1) {
+		runes = append(runes, string(r))
+	}
+	return runes
+}
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/language"
 )
 
 var text = "The quick brown fox jumps over the lazy dog."
@@ -56,3 +57,104 @@ func TestDisplayWidth(t *testing.T) {
 	input = "\033[43;30m" + input + "\033[00m"
 	checkEqual(t, DisplayWidth(input), want)
 }
+
+func TestDisplayWidthEscapes(t *testing.T) {
+	checkEqual(t, DisplayWidth("\x1b[1;31mred\x1b[0m"), 3)
+	checkEqual(t, DisplayWidth("\x1b[38;5;196mred\x1b[m"), 3)
+	checkEqual(t, DisplayWidth("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"), 4)
+	checkEqual(t, DisplayWidth("\x1b]8;;https://example.com\alink\x1b]8;;\a"), 4)
+	checkEqual(t, DisplayWidth("line\x1b[K"), 4)
+}
+
+func TestWrapEscapes(t *testing.T) {
+	// Without the escapes, each line would be exactly the limit.
+	got, _ := WrapString("\x1b[31mhello\x1b[0m \x1b[32mworld\x1b[0m", 5)
+	checkEqual(t, got, []string{"\x1b[31mhello\x1b[0m", "\x1b[32mworld\x1b[0m"})
+
+	got, _ = WrapString("\x1b[31mhello world\x1b[0m", 5)
+	checkEqual(t, got, []string{"\x1b[31mhello", "world\x1b[0m"})
+
+	link := "\x1b]8;;https://example.com/a\x1b\\docs\x1b]8;;\x1b\\"
+	got, _ = WrapString("see the "+link+" page", 9)
+	checkEqual(t, got, []string{"see the", link + " page"})
+}
+
+func TestTruncateEscapes(t *testing.T) {
+	checkEqual(t, truncate("abcdef", 4, "…"), "abc…")
+	checkEqual(t, truncate("abc", 4, "…"), "abc")
+	checkEqual(t, truncate("\x1b[31mabcdef\x1b[0m", 4, "…"), "\x1b[31mabc…\x1b[0m")
+	checkEqual(t, truncate("漢字漢字", 5, "…"), "漢字…")
+}
+
+func TestWrapTabs(t *testing.T) {
+	got, _ := WrapStringTabs("ab\tcd ef", 7, 4)
+	checkEqual(t, got, []string{"ab  cd", "ef"})
+
+	got, _ = WrapStringTabs("ab\tcd ef", 7, 0)
+	checkEqual(t, got, []string{"ab\tcd ef"})
+
+	got, _ = WrapStringTabs("a\tb\tc", 8, 4)
+	checkEqual(t, got, []string{"a   b", "c"})
+}
+
+func TestWrapStringWithWidth(t *testing.T) {
+	lines, width := WrapStringWithWidth("The quick brown fox", 12)
+	checkEqual(t, lines, []string{"The quick", "brown fox"})
+	checkEqual(t, width, 9)
+
+	lines, width = WrapStringWithWidth("supercalifragilistic is long", 5)
+	checkEqual(t, lines, []string{"supercalifragilistic", "is long"})
+	checkEqual(t, width, 20)
+
+	_, width = WrapStringWithWidth("\x1b[31mhello\x1b[0m", 10)
+	checkEqual(t, width, 5)
+}
+
+func TestTruncateAligned(t *testing.T) {
+	checkEqual(t, truncateAligned("abcdefgh", 5, "…", ALIGN_LEFT), "abcd…")
+	checkEqual(t, truncateAligned("abcdefgh", 5, "…", ALIGN_RIGHT), "…efgh")
+	checkEqual(t, truncateAligned("abcdefgh", 5, "…", ALIGN_CENTER), "…def…")
+	checkEqual(t, truncateAligned("abc", 5, "…", ALIGN_CENTER), "abc")
+	checkEqual(t, truncateAligned("\x1b[31m123456\x1b[0m", 4, "…", ALIGN_RIGHT), "\x1b[31m…456\x1b[0m")
+	checkEqual(t, truncateAligned("漢字漢字", 5, "…", ALIGN_RIGHT), "…漢字")
+}
+
+func TestTitleLanguage(t *testing.T) {
+	checkEqual(t, TitleLanguage("file_size", language.Und), "FILE SIZE")
+	checkEqual(t, TitleLanguage("şehir_id", language.Turkish), "ŞEHİR İD")
+	checkEqual(t, TitleLanguage("straße", language.German), "STRASSE")
+}
+
+func TestStripZeroWidth(t *testing.T) {
+	checkEqual(t, stripZeroWidth("a\u200bb\ufeff", false), "ab")
+	checkEqual(t, stripZeroWidth("\u200dab\u200c", false), "ab")
+	checkEqual(t, stripZeroWidth("a\u200db", false), "ab")
+
+	family := "👨\u200d👩\u200d👧"
+	checkEqual(t, stripZeroWidth(family, false), family)
+	checkEqual(t, stripZeroWidth(family, true), "👨👩👧")
+}
+
+func TestDisplayWidthWideAndCombining(t *testing.T) {
+	checkEqual(t, DisplayWidth("漢字"), 4)
+	checkEqual(t, DisplayWidth("한국어"), 6)
+	checkEqual(t, DisplayWidth("e\u0301te\u0301"), 3)
+	checkEqual(t, PadLeft("漢", " ", 4), "  漢")
+	checkEqual(t, PadRight("e\u0301", " ", 3), "e\u0301  ")
+
+	lines, width := WrapStringWithWidth("漢字 漢字漢字 かな", 6)
+	checkEqual(t, lines, []string{"漢字", "漢字漢字", "かな"})
+	checkEqual(t, width, 8)
+}
+
+func TestWrapStringHard(t *testing.T) {
+	lines, lim := WrapStringHard("see https://example.com/a/long/path now", 10)
+	checkEqual(t, lines, []string{"see", "https://ex", "ample.com/", "a/long/pat", "h now"})
+	checkEqual(t, lim, 10)
+
+	lines, _ = WrapStringHard("漢字漢字漢", 5)
+	checkEqual(t, lines, []string{"漢字", "漢字", "漢"})
+
+	lines, _ = WrapStringHard("\x1b[31mabcdef\x1b[0m", 4)
+	checkEqual(t, lines, []string{"\x1b[31mabcd", "ef\x1b[0m"})
+}
@@ -0,0 +1,57 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+// wrapKey identifies the text of a data cell of a column, wrapped to a
+// maximum width.
+type wrapKey struct {
+	col   int
+	width int
+	text  string
+}
+
+// wrappedCell holds the lines of a data cell and the width of the widest.
+type wrappedCell struct {
+	lines []string
+	width int
+}
+
+// SetWrapCache enables / disables remembering the lines of the data cells
+// of each column as they are appended, so that cells repeating the text of
+// a previous cell of their column are not wrapped again, which speeds up
+// appending many rows of repeated values. The lines are remembered for the
+// maximum width of the column, so changing it with SetColWidth or
+// SetColMaxWidth between appends is taken into account; other settings
+// changing the lines of the cells should be set before appending. The
+// cache is ignored with a value transformer, which depends on the row, and
+// is cleared when disabled.
+func (t *Table) SetWrapCache(cache bool) {
+	t.wrapCache = cache
+	if !cache {
+		t.wrapCells = nil
+	}
+}
+
+// cachedCellLines returns the lines of a cell and the width of the widest,
+// like cellLines, from the wrap cache when it is enabled. Rows share the
+// lines of identical cells, which are never changed in place.
+func (t *Table) cachedCellLines(str string, colKey, rowKey int) ([]string, int) {
+	if !t.wrapCache || rowKey < 0 || t.valueTransformer != nil {
+		return t.cellLines(str, colKey, rowKey)
+	}
+	key := wrapKey{col: colKey, width: t.wrapLimit(colKey, rowKey), text: str}
+	if cell, ok := t.wrapCells[key]; ok {
+		return cell.lines, cell.width
+	}
+	lines, width := t.cellLines(str, colKey, rowKey)
+	if t.wrapCells == nil {
+		t.wrapCells = make(map[wrapKey]wrappedCell)
+	}
+	t.wrapCells[key] = wrappedCell{lines: lines, width: width}
+	return lines, width
+}